@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 	"terminal-gameplay/src"
 )
 
@@ -16,5 +17,5 @@ func main() {
 
 	runner := src.NewRunner(fileManager, utils, viewBuilder)
 
-	runner.Start()
+	runner.Run(os.Args[1:])
 }
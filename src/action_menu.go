@@ -0,0 +1,272 @@
+package src
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// menuAction is an entry of the action menu
+type menuAction struct {
+	label string
+	// hint is the key that does the same outside the menu, if any
+	hint string
+	run  func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd)
+}
+
+// actionMenu lists what can be done with the selected item
+type actionMenu struct {
+	item    ListItem
+	actions []menuAction
+	cursor  int
+}
+
+// itemActions returns the actions available for an item of the current page
+func (m MultiPageViewModel) itemActions(item ListItem) []menuAction {
+	k := m.keys
+	switch {
+	case m.isGoToPage():
+		// Enter runs the action configured for the item, hint it there
+		defaultAction := goToAction(m.options, item.T)
+		openHint := func(action SelectionAction, binding string) string {
+			if action == defaultAction {
+				return helpKeys(k.Select)
+			}
+			return binding
+		}
+		open := func(action SelectionAction) func(MultiPageViewModel, ListItem) (tea.Model, tea.Cmd) {
+			return func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m.openSelection(item, action, Modifiers{})
+			}
+		}
+
+		actions := []menuAction{
+			{label: "cd", hint: openHint(OpenAction, ""), run: open(OpenAction)},
+			{label: "copy path", run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m, m.actions.CopyPath(item.D)
+			}},
+			{label: "open in editor", run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m, m.actions.OpenInEditor(item.D)
+			}},
+			{label: "open in file manager", run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m, m.actions.OpenInFileManager(item.D)
+			}},
+			{label: "new tmux window", hint: openHint(TmuxWindowAction, helpKeys(k.TmuxWindow)), run: open(TmuxWindowAction)},
+			{label: "tmux session", hint: openHint(TmuxSessionAction, helpKeys(k.TmuxSession)), run: open(TmuxSessionAction)},
+			{label: "new shell", hint: openHint(ShellAction, helpKeys(k.NewShell)), run: open(ShellAction)},
+		}
+		switch {
+		case item.Suggestion:
+			actions = append(actions, menuAction{label: "save to goTo", hint: helpKeys(k.Promote), run: MultiPageViewModel.runPromote})
+		case isEditablePage(m.currentPage) && !item.Generated:
+			actions = append(actions, menuAction{label: "edit entry", hint: helpKeys(k.Edit), run: MultiPageViewModel.runEdit})
+		}
+		return actions
+
+	case m.currentPage == CommandsPage:
+		// Enter runs commands where command_mode says, hint it there
+		shellHint, hereHint := helpKeys(k.Select), ""
+		if m.options.CommandMode == SubprocessCommandMode {
+			shellHint, hereHint = "", helpKeys(k.Select)
+		}
+		return []menuAction{
+			{label: "run in the shell", hint: shellHint, run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m.openSelection(item, OpenAction, Modifiers{})
+			}},
+			{label: "run here", hint: hereHint, run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m.startRun(item.T, item.D, "")
+			}},
+			{label: "insert at the prompt", run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m.openSelection(item, InsertAction, Modifiers{})
+			}},
+			{label: "copy", run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m, m.actions.CopyToClipboard(item.D)
+			}},
+			{label: "edit", hint: helpKeys(k.Edit), run: MultiPageViewModel.runEdit},
+		}
+
+	case m.currentPage == NotesPage:
+		return []menuAction{
+			{label: "copy", hint: helpKeys(k.Select), run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m.selectCurrent(OpenAction, Modifiers{})
+			}},
+			{label: "view", hint: helpKeys(k.View), run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m.openNoteViewer()
+			}},
+			{label: "edit", hint: helpKeys(k.Edit), run: MultiPageViewModel.runEdit},
+			{label: "toggle secret", hint: helpKeys(k.Secret), run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m.runSecretAction(toggleSecretAction, item)
+			}},
+		}
+
+	case m.currentPage == HistoryPage:
+		label, command, dir := historyRun(item)
+		return []menuAction{
+			{label: "re-run", hint: helpKeys(k.Rerun), run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m.startRun(label, command, dir)
+			}},
+			{label: "insert at the prompt", run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m.openSelection(item, InsertAction, Modifiers{})
+			}},
+			{label: "copy", run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m, m.actions.CopyToClipboard(item.D)
+			}},
+			{label: "copy directory", run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m, m.actions.CopyToClipboard(item.Dir)
+			}},
+		}
+
+	case m.currentPage == ClipboardPage:
+		return []menuAction{
+			{label: "copy", hint: helpKeys(k.Select), run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m, m.actions.CopyToClipboard(item.D)
+			}},
+			{label: "save as note", hint: helpKeys(k.Paste), run: func(m MultiPageViewModel, item ListItem) (tea.Model, tea.Cmd) {
+				return m.openNotePrompt(item.D)
+			}},
+		}
+	}
+	return nil
+}
+
+func (m MultiPageViewModel) runEdit(ListItem) (tea.Model, tea.Cmd) {
+	return m.editCurrent()
+}
+
+func (m MultiPageViewModel) runPromote(ListItem) (tea.Model, tea.Cmd) {
+	return m.promoteSuggestion()
+}
+
+// openActionMenu opens the action menu for the selected item
+func (m MultiPageViewModel) openActionMenu() (tea.Model, tea.Cmd) {
+	item, ok := m.selectedItem()
+	if !ok || m.actions == nil {
+		return m, nil
+	}
+	actions := m.itemActions(item)
+	if len(actions) == 0 {
+		return m, nil
+	}
+	m.actionMenu = &actionMenu{item: item, actions: actions}
+	m.errorMessage = ""
+	return m, nil
+}
+
+// updateActionMenu handles keys while the action menu is open
+func (m MultiPageViewModel) updateActionMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := *m.actionMenu
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit), key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.Menu):
+		m.actionMenu = nil
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		menu.cursor = (menu.cursor - 1 + len(menu.actions)) % len(menu.actions)
+
+	case key.Matches(msg, m.keys.Down):
+		menu.cursor = (menu.cursor + 1) % len(menu.actions)
+
+	case key.Matches(msg, m.keys.Select):
+		m.actionMenu = nil
+		return menu.actions[menu.cursor].run(m, menu.item)
+	}
+	m.actionMenu = &menu
+	return m, nil
+}
+
+// renderActionMenu renders the actions of the selected item with the keys
+// that run them directly
+func (m MultiPageViewModel) renderActionMenu() string {
+	menu := m.actionMenu
+	var b strings.Builder
+	b.WriteString(m.styles.Text("  Actions for "+menu.item.T, m.styles.TitleColor))
+	b.WriteString("\n\n")
+
+	width := 0
+	for _, action := range menu.actions {
+		width = max(width, len(action.label))
+	}
+	for i, action := range menu.actions {
+		line := fmt.Sprintf("%-*s  %s", width, action.label, m.styles.Text(action.hint, m.styles.MutedTitleColor))
+		if i == menu.cursor {
+			b.WriteString(m.styles.Text("  › ", m.styles.SelectedTitleColor))
+			b.WriteString(m.styles.Text(line, m.styles.SelectedTitleColor))
+		} else {
+			b.WriteString("    ")
+			b.WriteString(m.styles.Text(line, m.styles.MutedTitleColor))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	help := fmt.Sprintf("  %s %s navigate • %s run • %s close",
+		helpKeys(m.keys.Up), helpKeys(m.keys.Down), helpKeys(m.keys.Select), helpKeys(m.keys.Quit))
+	b.WriteString(m.styles.FooterStyle.Render(help + "\n"))
+	return b.String()
+}
+
+// resolvePath resolves the references in a goTo value and expands it
+func (a *Actions) resolvePath(value string) (string, error) {
+	return a.utils.ResolvePath(value)
+}
+
+// CopyPath copies the directory a goTo value points at
+func (a *Actions) CopyPath(value string) tea.Cmd {
+	return func() tea.Msg {
+		path, err := a.resolvePath(value)
+		if err != nil {
+			return actionResultMsg{err: fmt.Errorf("Failed to resolve the path: %v", err)}
+		}
+		if err := a.utils.CopyToClipboard(path, a.options.Clipboard); err != nil {
+			return actionResultMsg{err: fmt.Errorf("Failed to copy to clipboard: %v", err)}
+		}
+		return actionResultMsg{status: "✓ Copied " + path, copied: path}
+	}
+}
+
+// OpenInEditor opens a goTo directory in the editor, which starts there too
+func (a *Actions) OpenInEditor(value string) tea.Cmd {
+	path, err := a.resolvePath(value)
+	if err != nil {
+		return func() tea.Msg {
+			return actionResultMsg{err: fmt.Errorf("Failed to resolve the path: %v", err)}
+		}
+	}
+	cmd := editorCommand(path)
+	cmd.Dir = path
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return actionResultMsg{err: fmt.Errorf("Editor failed: %v", err)}
+		}
+		return actionResultMsg{}
+	})
+}
+
+// fileManagerCommand opens a directory in the desktop file manager
+func fileManagerCommand(path string) *exec.Cmd {
+	if runtime.GOOS == "darwin" {
+		return exec.Command("open", path)
+	}
+	return exec.Command("xdg-open", path)
+}
+
+// OpenInFileManager shows a goTo directory in the file manager, without
+// waiting for it to close
+func (a *Actions) OpenInFileManager(value string) tea.Cmd {
+	return func() tea.Msg {
+		path, err := a.resolvePath(value)
+		if err != nil {
+			return actionResultMsg{err: fmt.Errorf("Failed to resolve the path: %v", err)}
+		}
+		cmd := fileManagerCommand(path)
+		if err := cmd.Start(); err != nil {
+			return actionResultMsg{err: fmt.Errorf("Failed to open the file manager: %v", err)}
+		}
+		go cmd.Wait()
+		return actionResultMsg{status: "✓ Opened " + abbreviateHome(path)}
+	}
+}
@@ -0,0 +1,235 @@
+package src
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How long a status line stays in the footer
+const statusDuration = 2 * time.Second
+
+// Actions runs what selecting an item does when it doesn't need to leave the
+// TUI. Copying, settings and history maintenance run as tea.Cmds so the view
+// stays open; cd and run end the program and are handled by the Runner.
+type Actions struct {
+	fileManager FileManagerInterface
+	utils       UtilsInterface
+	// options is shared with the view, changes on the settings page apply here too
+	options *OptionsDTO
+}
+
+func NewActions(fm FileManagerInterface, u UtilsInterface, options *OptionsDTO) *Actions {
+	return &Actions{
+		fileManager: fm,
+		utils:       u,
+		options:     options,
+	}
+}
+
+// actionResultMsg reports the outcome of an action back to the view
+type actionResultMsg struct {
+	status string
+	err    error
+	// frequencyCleared asks the view to drop its frequency data
+	frequencyCleared bool
+	// copied is the text put on the clipboard, for the clipboard history
+	copied string
+}
+
+// clipboardPastedMsg carries the clipboard contents for a new note
+type clipboardPastedMsg struct {
+	text string
+	err  error
+}
+
+// clearStatusMsg hides the status line set by the action with the same id
+type clearStatusMsg struct {
+	id int
+}
+
+// ForItem returns the command for an action that keeps the view open, or
+// false when selecting the item leaves the TUI
+func (a *Actions) ForItem(page PageType, item ListItem) (tea.Cmd, bool) {
+	switch page {
+	case NotesPage, ClipboardPage:
+		return a.CopyToClipboard(item.D), true
+
+	case SettingsPage:
+		if item.T == "clear_frequency" {
+			return a.ClearFrequency(), true
+		}
+	}
+
+	return nil, false
+}
+
+// CopyToClipboard resolves the references in text, copies it and reports it
+// in the status line
+func (a *Actions) CopyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		resolved, err := a.utils.ResolveValue(text)
+		if err != nil {
+			return actionResultMsg{err: fmt.Errorf("Failed to resolve the note: %v", err)}
+		}
+		if err := a.utils.CopyToClipboard(resolved, a.options.Clipboard); err != nil {
+			return actionResultMsg{err: fmt.Errorf("Failed to copy to clipboard: %v", err)}
+		}
+		// The history keeps the references, not what they resolved to
+		return actionResultMsg{status: "✓ Copied to clipboard", copied: text}
+	}
+}
+
+// PasteFromClipboard reads the clipboard to create a note from it
+func (a *Actions) PasteFromClipboard() tea.Cmd {
+	return func() tea.Msg {
+		text, err := a.utils.PasteFromClipboard(a.options.Clipboard)
+		if err != nil {
+			return clipboardPastedMsg{err: fmt.Errorf("Failed to read the clipboard: %v", err)}
+		}
+		return clipboardPastedMsg{text: text}
+	}
+}
+
+// ClearFrequency empties the goTo frequency history
+func (a *Actions) ClearFrequency() tea.Cmd {
+	return func() tea.Msg {
+		_, err := UpdateGoToFrequency(a.fileManager, func(goToFrequency *GoToFrequencyDTO) {
+			*goToFrequency = *GetDefaultGoToFrequency()
+		})
+		if err != nil {
+			return actionResultMsg{err: fmt.Errorf("Failed to write goTo frequency: %v", err)}
+		}
+		return actionResultMsg{status: "✓ Frequency history cleared", frequencyCleared: true}
+	}
+}
+
+// SaveOptions writes options to options.json
+func (a *Actions) SaveOptions(options *OptionsDTO) error {
+	jsonStr, err := ToJSON(options)
+	if err != nil {
+		return fmt.Errorf("SaveOptions -> %v", err)
+	}
+	if err := a.fileManager.WriteOptionsContent(jsonStr); err != nil {
+		return fmt.Errorf("SaveOptions -> %v", err)
+	}
+	return nil
+}
+
+// SaveConfig writes config to config.json
+func (a *Actions) SaveConfig(config *ConfigDTO) error {
+	jsonStr, err := ToJSON(config)
+	if err != nil {
+		return fmt.Errorf("SaveConfig -> %v", err)
+	}
+	if err := a.fileManager.WriteConfigContent(jsonStr); err != nil {
+		return fmt.Errorf("SaveConfig -> %v", err)
+	}
+	return nil
+}
+
+// UpdateGoToFrequency changes goto_frequency.json under its lock and returns
+// what was saved
+func (a *Actions) UpdateGoToFrequency(update func(*GoToFrequencyDTO)) (*GoToFrequencyDTO, error) {
+	goToFrequency, err := UpdateGoToFrequency(a.fileManager, update)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateGoToFrequency -> %v", err)
+	}
+	return goToFrequency, nil
+}
+
+// LoadConfig reads config.json and the note files and expands goTo patterns
+func (a *Actions) LoadConfig() (*ConfigDTO, error) {
+	content, err := a.fileManager.GetConfigContent()
+	if err != nil {
+		return nil, fmt.Errorf("LoadConfig -> %v", err)
+	}
+
+	config, err := ParseJSONContent[ConfigDTO](content)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse config.json: %v", err)
+	}
+
+	config.NoteFiles, err = a.fileManager.GetNoteFiles()
+	if err != nil {
+		return nil, fmt.Errorf("LoadConfig -> %v", err)
+	}
+	expandGoToGlobs(config, a.options.GlobMaxDepth)
+	return config, nil
+}
+
+// LoadOptions reads options.json, keeping defaults for missing options
+func (a *Actions) LoadOptions() (*OptionsDTO, error) {
+	content, err := a.fileManager.GetOptionsContent()
+	if err != nil {
+		return nil, fmt.Errorf("LoadOptions -> %v", err)
+	}
+	if content == "" {
+		return GetDefaultOptions(), nil
+	}
+
+	options, err := ParseJSONContentWithDefaults(content, GetDefaultOptions())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse options.json: %v", err)
+	}
+	return options, nil
+}
+
+// LoadThemes registers the themes from the themes directory, then the ones
+// defined in options.json which take precedence
+func (a *Actions) LoadThemes(options *OptionsDTO) error {
+	themeFiles, err := a.fileManager.GetThemesContent()
+	if err != nil {
+		return fmt.Errorf("LoadThemes -> %v", err)
+	}
+
+	clearCustomThemes()
+	for name, content := range themeFiles {
+		theme, err := ParseJSONContent[ThemeDTO](content)
+		if err != nil {
+			return fmt.Errorf("Failed to parse theme %s: %v", name, err)
+		}
+		if err := ValidateTheme(name, *theme); err != nil {
+			return err
+		}
+		RegisterTheme(name, *theme)
+	}
+
+	for name, theme := range options.Themes {
+		if err := ValidateTheme(name, theme); err != nil {
+			return err
+		}
+		RegisterTheme(name, theme)
+	}
+	return nil
+}
+
+// LoadClipboardHistory reads the values copied in earlier sessions
+func (a *Actions) LoadClipboardHistory() (*ClipboardHistoryDTO, error) {
+	content, err := a.fileManager.GetClipboardHistoryContent()
+	if err != nil {
+		return nil, fmt.Errorf("LoadClipboardHistory -> %v", err)
+	}
+	if content == "" {
+		return GetDefaultClipboardHistory(), nil
+	}
+
+	history, err := ParseJSONContent[ClipboardHistoryDTO](content)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: %v", ClipboardHistoryFileName, err)
+	}
+	return history, nil
+}
+
+// SaveClipboardHistory writes the clipboard history
+func (a *Actions) SaveClipboardHistory(history *ClipboardHistoryDTO) error {
+	jsonStr, err := ToJSON(history)
+	if err != nil {
+		return fmt.Errorf("SaveClipboardHistory -> %v", err)
+	}
+	if err := a.fileManager.WriteClipboardHistoryContent(jsonStr); err != nil {
+		return fmt.Errorf("SaveClipboardHistory -> %v", err)
+	}
+	return nil
+}
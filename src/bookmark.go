@@ -0,0 +1,314 @@
+package src
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// noSectionLabel names the goTo entries above the first divider
+const noSectionLabel = "No section"
+
+// bookmarkPrompt is the label prompt for the directory being saved
+type bookmarkPrompt struct {
+	input textInputViewModel
+	path  string
+	// section is the divider key the entry goes under, empty for no section
+	section string
+}
+
+// currentDirectory returns the working directory, with ~ for the home
+// directory, and its name as the suggested label
+func currentDirectory(fm FileManagerInterface) (string, string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", "", fmt.Errorf("currentDirectory -> %v", err)
+	}
+	label, err := fm.GetCurrentDirectoryName()
+	if err != nil {
+		return "", "", fmt.Errorf("currentDirectory -> %v", err)
+	}
+	return abbreviateHome(dir), entryLabel(label), nil
+}
+
+// abbreviateHome writes paths inside the home directory starting with ~
+func abbreviateHome(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if rest, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return "~/" + rest
+	}
+	return path
+}
+
+// findGoToLabel returns the label of the goTo entry pointing at path,
+// directories matched by patterns included
+func findGoToLabel(config *ConfigDTO, path string, u UtilsInterface) (string, bool) {
+	target := filepath.Clean(u.ExpandPath(path))
+	for _, entry := range goToEntries(config) {
+		if filepath.Clean(u.ExpandPath(entry.Value)) == target {
+			return entry.Label, true
+		}
+	}
+	return "", false
+}
+
+// sectionKeys returns the divider keys of items in order
+func sectionKeys(items OrderedMap) []string {
+	keys := []string{}
+	for _, key := range items.Keys {
+		if isDividerKey(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// sectionAt returns the divider key of the section holding the item at index,
+// empty above the first divider. Pattern sections belong to the section the
+// pattern entry is in.
+func sectionAt(items []ListItem, index int) string {
+	if index >= len(items) {
+		index = len(items) - 1
+	}
+	for i := index; i >= 0; i-- {
+		if items[i].IsDiv && !items[i].Generated {
+			return items[i].T
+		}
+	}
+	return ""
+}
+
+// insertInSection adds an entry at the end of the section started by the
+// divider key section, or before the first divider when section is empty
+func insertInSection(items *OrderedMap, section, key, value string) {
+	if items.Values == nil {
+		items.Values = map[string]string{}
+	}
+
+	index := len(items.Keys)
+	inSection := section == ""
+	for i, k := range items.Keys {
+		if !isDividerKey(k) {
+			continue
+		}
+		if inSection {
+			index = i
+			break
+		}
+		inSection = k == section
+	}
+
+	items.Keys = append(items.Keys[:index], append([]string{key}, items.Keys[index:]...)...)
+	items.Values[key] = value
+}
+
+// AddHere saves the working directory as a goTo entry, asking for its label
+// unless given and for its section when goTo has dividers
+func (r *Runner) AddHere(args []string) {
+	_, config, _ := r.load()
+	styles := DefaultStyles()
+
+	path, label, err := currentDirectory(r.fileManager)
+	if err != nil {
+		r.utils.HandleError(err, "Failed to read the current directory")
+	}
+
+	if existing, ok := findGoToLabel(config, path, r.utils); ok {
+		fmt.Println(styles.Text(fmt.Sprintf("%s is already saved as %q", path, existing), styles.FooterColor))
+		return
+	}
+
+	if len(args) > 0 {
+		label = strings.TrimSpace(strings.Join(args, " "))
+		if err := checkBookmarkLabel(config.GoTo, label); err != nil {
+			r.utils.ExitWithError(err.Error())
+		}
+	} else {
+		title := fmt.Sprintf("Label for %s", path)
+		for {
+			label = r.viewBuilder.NewTextFieldView(title, "label", label)
+			r.utils.ValidateInput(label)
+			label = strings.TrimSpace(label)
+			err := checkBookmarkLabel(config.GoTo, label)
+			if err == nil {
+				break
+			}
+			title = fmt.Sprintf("%v. Label for %s", err, path)
+		}
+	}
+
+	section := r.chooseSection(config.GoTo)
+	insertInSection(&config.GoTo, section, label, path)
+	r.saveConfig(config)
+	fmt.Println(styles.Text(fmt.Sprintf("✓ Saved %s as %q", path, label), styles.AquamarineColor))
+}
+
+// checkBookmarkLabel rejects a typed label that is empty, taken, or that
+// would turn the entry into a divider
+func checkBookmarkLabel(goTo OrderedMap, label string) error {
+	if label == "" {
+		return fmt.Errorf("The label can't be empty")
+	}
+	if isDividerKey(label) {
+		return fmt.Errorf("Labels starting with div are dividers, try %q", entryLabel(label))
+	}
+	if _, taken := goTo.Values[label]; taken {
+		return fmt.Errorf("A goTo entry named %q already exists", label)
+	}
+	return nil
+}
+
+// chooseSection asks which divider section a new goTo entry goes under and
+// returns its key, empty for no section
+func (r *Runner) chooseSection(goTo OrderedMap) string {
+	keys := sectionKeys(goTo)
+	if len(keys) == 0 {
+		return ""
+	}
+
+	// Describe each section by the entries already in it
+	entries := map[string][]string{}
+	section := ""
+	for _, key := range goTo.Keys {
+		if isDividerKey(key) {
+			section = key
+			continue
+		}
+		entries[section] = append(entries[section], key)
+	}
+
+	sections := append([]string{""}, keys...)
+	items := make([]ListItem, len(sections))
+	for i, key := range sections {
+		title := noSectionLabel
+		if key != "" {
+			title = goTo.Values[key]
+		}
+		description := strings.Join(entries[key], ", ")
+		if description == "" {
+			description = "empty"
+		}
+		items[i] = ListItem{T: title, D: description}
+	}
+
+	chosen := r.viewBuilder.NewListView("Add under which section?", items, 14)
+	r.utils.ValidateInput(chosen.T)
+	for i, item := range items {
+		if item == chosen {
+			return sections[i]
+		}
+	}
+	return ""
+}
+
+// openBookmarkPrompt asks for the label of the working directory before
+// saving it to goTo. On the goTo page it goes under the selected section.
+func (m MultiPageViewModel) openBookmarkPrompt() (tea.Model, tea.Cmd) {
+	if m.actions == nil {
+		return m, nil
+	}
+
+	path, label, err := currentDirectory(m.actions.fileManager)
+	if err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to read the current directory: %v", err)
+		return m, nil
+	}
+	if existing, ok := findGoToLabel(m.config, path, m.actions.utils); ok {
+		m.errorMessage = fmt.Sprintf("%s is already saved as %q", path, existing)
+		return m, nil
+	}
+
+	section := ""
+	if m.currentPage == GoToPage && !m.searchMode {
+		section = sectionAt(m.goToList, m.cursor)
+	}
+
+	value := new(string)
+	input := TextFieldViewModel(fmt.Sprintf("Label for %s", path), "label", value)
+	input.textInput.SetValue(label)
+	input.textInput.CursorEnd()
+
+	m.bookmarkPrompt = &bookmarkPrompt{input: input, path: path, section: section}
+	m.errorMessage = ""
+	return m, nil
+}
+
+// updateBookmarkPrompt handles keys while the bookmark prompt is open
+func (m MultiPageViewModel) updateBookmarkPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.bookmarkPrompt = nil
+		m.errorMessage = ""
+		return m, nil
+
+	case tea.KeyEnter:
+		label := strings.TrimSpace(m.bookmarkPrompt.input.textInput.Value())
+		if err := checkBookmarkLabel(m.config.GoTo, label); err != nil {
+			m.bookmarkPrompt.input.errors = true
+			m.errorMessage = err.Error()
+			return m, nil
+		}
+		return m.addBookmark(label)
+	}
+
+	prompt := *m.bookmarkPrompt
+	model, cmd := prompt.input.Update(msg)
+	prompt.input = model.(textInputViewModel)
+	m.bookmarkPrompt = &prompt
+	return m, cmd
+}
+
+// addBookmark saves the prompted directory to config.json and selects it
+func (m MultiPageViewModel) addBookmark(label string) (tea.Model, tea.Cmd) {
+	prompt := m.bookmarkPrompt
+	insertInSection(&m.config.GoTo, prompt.section, label, prompt.path)
+
+	m.bookmarkPrompt = nil
+	m.errorMessage = ""
+	m.setConfig(m.config)
+
+	if m.currentPage == GoToPage && !m.searchMode {
+		for i, item := range m.goToList {
+			if !item.IsDiv && item.T == label {
+				m.cursor = i
+				m.updateLayout()
+				break
+			}
+		}
+	}
+
+	if err := m.actions.SaveConfig(m.config); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to save config: %v", err)
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		return actionResultMsg{status: fmt.Sprintf("✓ Saved %s as %q", prompt.path, label)}
+	}
+}
+
+// renderBookmarkPrompt renders the label prompt with the section the entry goes to
+func (m MultiPageViewModel) renderBookmarkPrompt() string {
+	section := noSectionLabel
+	if m.bookmarkPrompt.section != "" {
+		section = m.config.GoTo.Values[m.bookmarkPrompt.section]
+	}
+
+	var b strings.Builder
+	b.WriteString(m.bookmarkPrompt.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.Text("  Section: "+section, m.styles.MutedTitleColor))
+	b.WriteString("\n")
+	if m.errorMessage != "" {
+		b.WriteString(m.renderFooterLine(m.errorMessage, m.styles.ErrorColor))
+	}
+	return b.String()
+}
@@ -0,0 +1,227 @@
+package src
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// Clipboard backends accepted by the "clipboard" option
+const (
+	AutoClipboard    = "auto"
+	PbcopyClipboard  = "pbcopy"
+	WlCopyClipboard  = "wl-copy"
+	XclipClipboard   = "xclip"
+	XselClipboard    = "xsel"
+	OSC52Clipboard   = "osc52"
+	defaultClipboard = AutoClipboard
+
+	// ClearClipboardCommand is the hidden subcommand that clears the clipboard later
+	ClearClipboardCommand = "clear-clipboard"
+	// Seconds before a copied secret is cleared by default
+	defaultClipboardClearSeconds = 30
+)
+
+// ClipboardBackends returns the values of the "clipboard" option
+func ClipboardBackends() []string {
+	return []string{
+		AutoClipboard,
+		PbcopyClipboard,
+		WlCopyClipboard,
+		XclipClipboard,
+		XselClipboard,
+		OSC52Clipboard,
+	}
+}
+
+// clipboardCommands maps the command line backends to their arguments
+var clipboardCommands = map[string][]string{
+	PbcopyClipboard: {"pbcopy"},
+	WlCopyClipboard: {"wl-copy"},
+	XclipClipboard:  {"xclip", "-selection", "clipboard"},
+	XselClipboard:   {"xsel", "--clipboard", "--input"},
+}
+
+// pasteCommands maps the command line backends to the tools that read the clipboard
+var pasteCommands = map[string][]string{
+	PbcopyClipboard: {"pbpaste"},
+	WlCopyClipboard: {"wl-paste", "--no-newline"},
+	XclipClipboard:  {"xclip", "-selection", "clipboard", "-o"},
+	XselClipboard:   {"xsel", "--clipboard", "--output"},
+}
+
+// clipboardOrder lists the backends tried by "auto". Over SSH the local
+// tools would fill the remote machine's clipboard, so only OSC 52 is used.
+func clipboardOrder() []string {
+	order := []string{PbcopyClipboard, WlCopyClipboard, XclipClipboard, XselClipboard, OSC52Clipboard}
+	if isSSHSession() {
+		order = []string{OSC52Clipboard}
+	}
+	return order
+}
+
+func isSSHSession() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// copyWithBackend copies text with a single backend
+func copyWithBackend(backend, text string) error {
+	if backend == OSC52Clipboard {
+		return copyWithOSC52(text)
+	}
+
+	args, ok := clipboardCommands[backend]
+	if !ok {
+		return fmt.Errorf("unknown backend")
+	}
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return fmt.Errorf("not installed")
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%v: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// pasteWithBackend reads the clipboard with a single backend
+func pasteWithBackend(backend string) (string, error) {
+	if backend == OSC52Clipboard {
+		return "", fmt.Errorf("reading is not supported")
+	}
+
+	args, ok := pasteCommands[backend]
+	if !ok {
+		return "", fmt.Errorf("unknown backend")
+	}
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return "", fmt.Errorf("not installed")
+	}
+
+	var stderr strings.Builder
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%v: %s", err, msg)
+		}
+		return "", err
+	}
+	return string(out), nil
+}
+
+// copyWithOSC52 asks the terminal to set the clipboard with an OSC 52 escape
+// sequence, wrapped for tmux and screen so they pass it through
+func copyWithOSC52(text string) error {
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+
+	// Write to the terminal directly, stdout belongs to the TUI
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		_, err = seq.WriteTo(os.Stderr)
+		return err
+	}
+	defer tty.Close()
+
+	_, err = seq.WriteTo(tty)
+	return err
+}
+
+// ScheduleClipboardClear starts a background copy of tg that clears the
+// clipboard after seconds, unless something else was copied meanwhile. It
+// outlives the TUI and keeps the terminal so OSC 52 can clear it too.
+//
+// The copy recognizes the value by an HMAC under a key made for this copy
+// only, handed over on stdin. Nothing on its command line, which other users
+// can read, helps guessing the value.
+func ScheduleClipboardClear(text, backend string, seconds int) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("ScheduleClipboardClear -> %v", err)
+	}
+
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("ScheduleClipboardClear -> %v", err)
+	}
+
+	cmd := exec.Command(executable, ClearClipboardCommand, strconv.Itoa(seconds), backend)
+	// A process group of its own keeps it out of the way of ctrl+c
+	detachProcess(cmd)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("ScheduleClipboardClear -> %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("ScheduleClipboardClear -> %v", err)
+	}
+	_, err = fmt.Fprintf(stdin, "%s %s\n", hex.EncodeToString(key), clipboardMAC(key, text))
+	stdin.Close()
+	if err != nil {
+		cmd.Process.Kill()
+		return fmt.Errorf("ScheduleClipboardClear -> %v", err)
+	}
+	return cmd.Process.Release()
+}
+
+// RunClipboardClearer is the clear-clipboard subcommand: it reads a key and
+// an HMAC from stdin, waits, then clears the clipboard if it still holds the
+// value with that HMAC
+func RunClipboardClearer(args []string, stdin io.Reader) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: %s <seconds> <backend>, with \"<key> <hmac>\" on stdin", ClearClipboardCommand)
+	}
+	seconds, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("RunClipboardClearer -> %v", err)
+	}
+	backend := args[1]
+
+	var keyHex, mac string
+	if _, err := fmt.Fscanln(stdin, &keyHex, &mac); err != nil {
+		return fmt.Errorf("RunClipboardClearer -> %v", err)
+	}
+	key, err := hex.DecodeString(keyHex)
+	if err != nil {
+		return fmt.Errorf("RunClipboardClearer -> %v", err)
+	}
+
+	time.Sleep(time.Duration(seconds) * time.Second)
+
+	// Leave the clipboard alone when it now holds something else. When it
+	// can't be read, clearing is the safe choice.
+	utils := NewUtils()
+	if current, err := utils.PasteFromClipboard(backend); err == nil && !hmac.Equal([]byte(clipboardMAC(key, current)), []byte(mac)) {
+		return nil
+	}
+	return utils.CopyToClipboard("", backend)
+}
+
+// clipboardMAC returns the hex HMAC-SHA256 of text under key
+func clipboardMAC(key []byte, text string) string {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(text))
+	return hex.EncodeToString(h.Sum(nil))
+}
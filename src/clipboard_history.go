@@ -0,0 +1,98 @@
+package src
+
+import (
+	"fmt"
+	"time"
+)
+
+// Number of copied values kept by default
+const defaultClipboardHistorySize = 10
+
+type ClipboardEntry struct {
+	Text     string    `json:"text"`
+	CopiedAt time.Time `json:"copied_at"`
+}
+
+// ClipboardHistoryDTO keeps the last values copied by tg, newest first
+type ClipboardHistoryDTO struct {
+	Entries []ClipboardEntry `json:"entries"`
+}
+
+func GetDefaultClipboardHistory() *ClipboardHistoryDTO {
+	return &ClipboardHistoryDTO{
+		Entries: []ClipboardEntry{},
+	}
+}
+
+// Add puts text at the top of the history, dropping an older copy of the same
+// text and anything past limit
+func (h *ClipboardHistoryDTO) Add(text string, limit int) {
+	entries := []ClipboardEntry{{Text: text, CopiedAt: time.Now()}}
+	for _, entry := range h.Entries {
+		if entry.Text != text {
+			entries = append(entries, entry)
+		}
+	}
+	h.Entries = entries
+	h.Trim(limit)
+}
+
+// Trim drops the oldest entries past limit
+func (h *ClipboardHistoryDTO) Trim(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+	if len(h.Entries) > limit {
+		h.Entries = h.Entries[:limit]
+	}
+}
+
+// IsEmpty returns true if nothing was copied yet
+func (h *ClipboardHistoryDTO) IsEmpty() bool {
+	return len(h.Entries) == 0
+}
+
+// buildClipboardList lists the history labeled by when each value was copied
+func buildClipboardList(history *ClipboardHistoryDTO) []ListItem {
+	items := []ListItem{}
+	for _, entry := range history.Entries {
+		items = append(items, ListItem{
+			T: entry.CopiedAt.Format("Jan 2 15:04:05"),
+			D: entry.Text,
+		})
+	}
+	return items
+}
+
+// setClipboardHistory replaces the history shown on the clipboard page
+func (m *MultiPageViewModel) setClipboardHistory(history *ClipboardHistoryDTO) {
+	m.clipboardHistory = history
+	m.clipboardList = buildClipboardList(history)
+	m.rebuildPages()
+}
+
+// recordCopy adds a copied value to the history when it is enabled
+func (m *MultiPageViewModel) recordCopy(text string) {
+	if m.options.ClipboardHistory <= 0 {
+		return
+	}
+	m.clipboardHistory.Add(text, m.options.ClipboardHistory)
+	m.saveClipboardHistory()
+
+	// The copied entry moved to the top
+	if m.currentPage == ClipboardPage && !m.searchMode {
+		m.cursor = 0
+		m.viewportStart = 0
+	}
+}
+
+// saveClipboardHistory refreshes the clipboard page and writes the history
+func (m *MultiPageViewModel) saveClipboardHistory() {
+	m.setClipboardHistory(m.clipboardHistory)
+	if m.actions == nil {
+		return
+	}
+	if err := m.actions.SaveClipboardHistory(m.clipboardHistory); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to save clipboard history: %v", err)
+	}
+}
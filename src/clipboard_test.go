@@ -0,0 +1,87 @@
+//go:build !windows
+
+package src
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeClipboard puts a pbcopy and pbpaste on PATH that keep the clipboard
+// in a file, and returns that file
+func fakeClipboard(t *testing.T, content string) string {
+	t.Helper()
+	bin := t.TempDir()
+	board := filepath.Join(t.TempDir(), "clipboard")
+	if err := os.WriteFile(board, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	writeScript(t, filepath.Join(bin, "pbcopy"), "#!/bin/sh\ncat > \""+board+"\"\n")
+	writeScript(t, filepath.Join(bin, "pbpaste"), "#!/bin/sh\ncat \""+board+"\"\n")
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return board
+}
+
+func clearerInput(key []byte, text string) *strings.Reader {
+	return strings.NewReader(fmt.Sprintf("%s %s\n", hex.EncodeToString(key), clipboardMAC(key, text)))
+}
+
+func TestClipboardClearerClearsCopiedSecret(t *testing.T) {
+	board := fakeClipboard(t, "hunter2")
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	if err := RunClipboardClearer([]string{"0", PbcopyClipboard}, clearerInput(key, "hunter2")); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(board); len(content) != 0 {
+		t.Errorf("clipboard holds %q, want it cleared", content)
+	}
+}
+
+func TestClipboardClearerKeepsNewerContent(t *testing.T) {
+	board := fakeClipboard(t, "copied since")
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	if err := RunClipboardClearer([]string{"0", PbcopyClipboard}, clearerInput(key, "hunter2")); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(board); string(content) != "copied since" {
+		t.Errorf("clipboard holds %q, want it untouched", content)
+	}
+}
+
+func TestClipboardClearerRejectsBadInput(t *testing.T) {
+	fakeClipboard(t, "hunter2")
+
+	tests := map[string]struct {
+		args  []string
+		stdin string
+	}{
+		"missing backend": {args: []string{"0"}, stdin: "00 00\n"},
+		"bad seconds":     {args: []string{"soon", PbcopyClipboard}, stdin: "00 00\n"},
+		"no stdin":        {args: []string{"0", PbcopyClipboard}, stdin: ""},
+		"key not hex":     {args: []string{"0", PbcopyClipboard}, stdin: "zz 00\n"},
+	}
+	for name, tt := range tests {
+		if err := RunClipboardClearer(tt.args, strings.NewReader(tt.stdin)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestClipboardMAC(t *testing.T) {
+	key, other := []byte("key one"), []byte("key two")
+	if clipboardMAC(key, "hunter2") != clipboardMAC(key, "hunter2") {
+		t.Error("the MAC of the same text changed")
+	}
+	if clipboardMAC(key, "hunter2") == clipboardMAC(other, "hunter2") {
+		t.Error("different keys gave the same MAC")
+	}
+	if strings.Contains(clipboardMAC(key, "hunter2"), hex.EncodeToString([]byte("hunter2"))) {
+		t.Error("the MAC holds the text")
+	}
+}
@@ -0,0 +1,430 @@
+package src
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Values of the command_mode option
+const (
+	// ShellCommandMode runs commands in the calling shell once tg exits
+	ShellCommandMode = "shell"
+	// SubprocessCommandMode runs commands inside tg and shows their output
+	SubprocessCommandMode = "subprocess"
+)
+
+// CommandModes returns the command modes in the order the settings page cycles through them
+func CommandModes() []string {
+	return []string{ShellCommandMode, SubprocessCommandMode}
+}
+
+const (
+	// How long a stopped command may keep its output open
+	commandWaitDelay = 2 * time.Second
+	// Output kept for the viewer, older output is dropped
+	maxRunOutput = 1 << 20
+	// Runs kept in the history, the file is trimmed once it holds twice as many
+	maxRunHistory = 200
+)
+
+// RunRecord is a command run inside tg, one line of run_history.jsonl
+type RunRecord struct {
+	Label      string    `json:"label"`
+	Command    string    `json:"command"`
+	Cwd        string    `json:"cwd"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+	// ExitCode is -1 when the command couldn't start or was stopped
+	ExitCode int `json:"exit_code"`
+}
+
+// Duration returns how long the run took
+func (r RunRecord) Duration() time.Duration {
+	return time.Duration(r.DurationMs) * time.Millisecond
+}
+
+// runOutputMsg carries output of the running command
+type runOutputMsg struct {
+	id   int
+	text string
+}
+
+// runFinishedMsg reports that the command exited
+type runFinishedMsg struct {
+	id     int
+	record RunRecord
+	err    error
+}
+
+// commandRun is a command running or run inside the TUI, with its output
+type commandRun struct {
+	id       int
+	label    string
+	command  string
+	dir      string
+	output   []byte
+	viewport viewport.Model
+	running  bool
+	record   RunRecord
+	err      error
+	// messages delivers output, then the result
+	messages chan tea.Msg
+	cancel   context.CancelFunc
+	// closed is closed with the viewer, so nothing waits for it to read anymore
+	closed chan struct{}
+}
+
+// runWriter forwards output to the viewer while it is open
+type runWriter struct {
+	id       int
+	messages chan<- tea.Msg
+	closed   <-chan struct{}
+}
+
+func (w runWriter) Write(p []byte) (int, error) {
+	select {
+	case w.messages <- runOutputMsg{id: w.id, text: string(p)}:
+	case <-w.closed:
+	}
+	return len(p), nil
+}
+
+// RunCommand runs a command in dir, streaming its output as messages, and
+// records the run in the history once it exits
+func (a *Actions) RunCommand(run *commandRun) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	run.messages = make(chan tea.Msg)
+	run.cancel = cancel
+	run.closed = make(chan struct{})
+
+	go func() {
+		defer cancel()
+		record := RunRecord{Label: run.label, Command: run.command, Cwd: run.dir, StartedAt: time.Now(), ExitCode: -1}
+		finish := func(err error) {
+			record.DurationMs = time.Since(record.StartedAt).Milliseconds()
+			if saveErr := a.AppendRunHistory(record); saveErr != nil && err == nil {
+				err = fmt.Errorf("Failed to save the run history: %v", saveErr)
+			}
+			select {
+			case run.messages <- runFinishedMsg{id: run.id, record: record, err: err}:
+			case <-run.closed:
+			}
+		}
+
+		// References are resolved on every run, like when selected
+		command, err := a.utils.ResolveValue(run.command)
+		if err != nil {
+			finish(fmt.Errorf("Failed to resolve %s: %v", run.label, err))
+			return
+		}
+		output := runWriter{id: run.id, messages: run.messages, closed: run.closed}
+		record.ExitCode, err = a.utils.ExecuteCommand(ctx, command, run.dir, output)
+		if ctx.Err() != nil {
+			err = fmt.Errorf("Stopped")
+		}
+		finish(err)
+	}()
+
+	return waitForRun(run)
+}
+
+// waitForRun waits for the next output or the result of a run, until its
+// viewer closes
+func waitForRun(run *commandRun) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case msg := <-run.messages:
+			return msg
+		case <-run.closed:
+			return nil
+		}
+	}
+}
+
+// AppendRunHistory adds a run to run_history.jsonl, trimming old runs now and then
+func (a *Actions) AppendRunHistory(record RunRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("AppendRunHistory -> %v", err)
+	}
+	if err := a.fileManager.AppendRunHistoryContent(string(line)); err != nil {
+		return fmt.Errorf("AppendRunHistory -> %v", err)
+	}
+
+	content, err := a.fileManager.GetRunHistoryContent()
+	if err != nil {
+		return fmt.Errorf("AppendRunHistory -> %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	if len(lines) <= 2*maxRunHistory {
+		return nil
+	}
+	lines = lines[len(lines)-maxRunHistory:]
+	if err := a.fileManager.WriteRunHistoryContent(strings.Join(lines, "\n") + "\n"); err != nil {
+		return fmt.Errorf("AppendRunHistory -> %v", err)
+	}
+	return nil
+}
+
+// LoadRunHistory reads the latest runs, newest first. Lines that don't parse
+// are skipped, a run cut short while writing shouldn't hide the others.
+func (a *Actions) LoadRunHistory() ([]RunRecord, error) {
+	content, err := a.fileManager.GetRunHistoryContent()
+	if err != nil {
+		return nil, fmt.Errorf("LoadRunHistory -> %v", err)
+	}
+
+	records := []RunRecord{}
+	lines := strings.Split(content, "\n")
+	for i := len(lines) - 1; i >= 0 && len(records) < maxRunHistory; i-- {
+		var record RunRecord
+		if strings.TrimSpace(lines[i]) == "" || json.Unmarshal([]byte(lines[i]), &record) != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// runStatus summarizes how a run ended, e.g. "✓ 0 · 1.2s"
+func runStatus(record RunRecord) string {
+	mark := "✓"
+	if record.ExitCode != 0 {
+		mark = "✗"
+	}
+	return fmt.Sprintf("%s %d · %s", mark, record.ExitCode, record.Duration().Round(100*time.Millisecond))
+}
+
+// buildHistoryList lists past runs labeled by command, status and time
+func buildHistoryList(records []RunRecord) []ListItem {
+	items := []ListItem{}
+	for _, record := range records {
+		items = append(items, ListItem{
+			T:   fmt.Sprintf("%s  %s · %s", record.Label, runStatus(record), record.StartedAt.Format("Jan 2 15:04")),
+			D:   record.Command,
+			Dir: record.Cwd,
+		})
+	}
+	return items
+}
+
+// historyRun returns the label, command and directory of a past run
+func historyRun(item ListItem) (string, string, string) {
+	label := item.T
+	if index := strings.LastIndex(label, "  "); index >= 0 {
+		label = label[:index]
+	}
+	return label, item.D, item.Dir
+}
+
+// setRunHistory replaces the runs shown on the history page
+func (m *MultiPageViewModel) setRunHistory(records []RunRecord) {
+	m.historyList = buildHistoryList(records)
+	m.rebuildPages()
+	if m.currentPage == HistoryPage && m.searchMode {
+		m.updateFilteredList()
+	}
+}
+
+// startRun runs a command in dir inside the TUI, showing its output
+func (m MultiPageViewModel) startRun(label, command, dir string) (tea.Model, tea.Cmd) {
+	if m.actions == nil {
+		return m, nil
+	}
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to read the current directory: %v", err)
+			return m, nil
+		}
+		dir = wd
+	}
+	if m.commandRun != nil {
+		m.closeRun()
+	}
+
+	width, height := m.noteViewerSize()
+	m.runID++
+	vp := viewport.New(width, height)
+	vp.KeyMap = m.keys.ViewportKeyMap()
+	run := &commandRun{
+		id:       m.runID,
+		label:    label,
+		command:  command,
+		dir:      dir,
+		viewport: vp,
+		running:  true,
+	}
+	cmd := m.actions.RunCommand(run)
+	m.commandRun = run
+	m.errorMessage = ""
+	return m, cmd
+}
+
+// closeRun closes the output viewer, stopping the command if it still runs
+func (m *MultiPageViewModel) closeRun() {
+	m.commandRun.cancel()
+	close(m.commandRun.closed)
+	m.commandRun = nil
+}
+
+// updateRun applies output and the result of the running command
+func (m MultiPageViewModel) updateRun(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.commandRun == nil {
+		return m, nil
+	}
+	run := *m.commandRun
+
+	switch msg := msg.(type) {
+	case runOutputMsg:
+		if msg.id != run.id {
+			return m, nil
+		}
+		run.output = append(run.output, msg.text...)
+		if len(run.output) > maxRunOutput {
+			run.output = run.output[len(run.output)-maxRunOutput:]
+		}
+		run.setContent()
+		m.commandRun = &run
+		return m, waitForRun(&run)
+
+	case runFinishedMsg:
+		if msg.id != run.id {
+			return m, nil
+		}
+		run.running = false
+		run.record = msg.record
+		run.err = msg.err
+		m.commandRun = &run
+
+		// Show the new run on the history page
+		records, err := m.actions.LoadRunHistory()
+		if err != nil {
+			m.errorMessage = err.Error()
+			return m, nil
+		}
+		m.setRunHistory(records)
+		return m, nil
+	}
+	return m, nil
+}
+
+// setContent shows the output, following it while the end is in view
+func (run *commandRun) setContent() {
+	follow := run.viewport.AtBottom()
+	run.viewport.SetContent(normalizeOutput(string(run.output)))
+	if follow {
+		run.viewport.GotoBottom()
+	}
+}
+
+// normalizeOutput keeps what a terminal would show of lines rewritten with
+// carriage returns, as progress bars do
+func normalizeOutput(output string) string {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if index := strings.LastIndex(line, "\r"); index >= 0 {
+			lines[i] = line[index+1:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// resizeRun fits the output viewer to the terminal
+func (m *MultiPageViewModel) resizeRun() {
+	width, height := m.noteViewerSize()
+	m.commandRun.viewport.Width = width
+	m.commandRun.viewport.Height = height
+	m.commandRun.setContent()
+}
+
+// updateRunViewer scrolls the output, stops the command, runs it again or
+// closes the viewer
+func (m MultiPageViewModel) updateRunViewer(msg tea.Msg) (tea.Model, tea.Cmd) {
+	run := m.commandRun
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.ForceQuit) && run.running:
+			// ctrl+c stops the command, like in the shell
+			run.cancel()
+			return m, nil
+
+		case key.Matches(msg, m.keys.ForceQuit):
+			*m.selected = Selection{Action: QuitAction}
+			m.closeRun()
+			m.quitting = true
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Quit):
+			m.closeRun()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Select) && !run.running:
+			m.closeRun()
+			return m, nil
+
+		case key.Matches(msg, m.keys.Rerun) && !run.running:
+			return m.startRun(run.label, run.command, run.dir)
+		}
+	}
+
+	viewer := *run
+	var cmd tea.Cmd
+	viewer.viewport, cmd = viewer.viewport.Update(msg)
+	m.commandRun = &viewer
+	return m, cmd
+}
+
+// renderRunViewer renders the command, its state and its output in place of the list
+func (m MultiPageViewModel) renderRunViewer() string {
+	width, _ := m.noteViewerSize()
+	run := m.commandRun
+
+	var state string
+	switch {
+	case run.running:
+		state = m.styles.Text("running…", m.styles.FooterColor)
+	case run.err != nil:
+		state = m.styles.Text(run.err.Error(), m.styles.ErrorColor)
+	case run.record.ExitCode == 0:
+		state = m.styles.Text(runStatus(run.record), m.styles.AquamarineColor)
+	default:
+		state = m.styles.Text(runStatus(run.record), m.styles.ErrorColor)
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.SelectedTitleColor)
+	var b strings.Builder
+	b.WriteString(" ")
+	b.WriteString(titleStyle.Render(ansi.Truncate("$ "+strings.ReplaceAll(run.command, "\n", " ⏎ "), width-4, "…")))
+	b.WriteString("\n ")
+	b.WriteString(ansi.Truncate(state, width-2, "…"))
+	b.WriteString("\n")
+	b.WriteString(run.viewport.View())
+	return b.String()
+}
+
+// RunViewerHelpText builds the footer help line of the output viewer
+func (k KeyMap) RunViewerHelpText(running bool) string {
+	if running {
+		return strings.Join([]string{
+			k.ScrollHelpText(),
+			helpKeys(k.ForceQuit) + " stop",
+			helpKeys(k.Quit) + " close",
+		}, " • ")
+	}
+	return strings.Join([]string{
+		k.ScrollHelpText(),
+		helpKeys(k.Rerun) + " re-run",
+		helpKeys(k.Quit) + " close",
+	}, " • ")
+}
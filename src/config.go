@@ -4,12 +4,24 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 type ConfigDTO struct {
 	GoTo     OrderedMap `json:"goTo"`
 	Commands OrderedMap `json:"commands"`
 	Notes    OrderedMap `json:"notes"`
+	// NoteFiles are the notes kept as files in the notes directory
+	NoteFiles []NoteFile `json:"-"`
+	// GoToGlobs are the goTo patterns expanded into directories at load time
+	GoToGlobs []GoToGlob `json:"-"`
+}
+
+// NoteFile is a note stored in its own file, labeled by the file name
+type NoteFile struct {
+	Label   string
+	Path    string
+	Content string
 }
 
 type ConfigItem struct {
@@ -21,23 +33,14 @@ type ConfigItem struct {
 type OrderedMap struct {
 	Keys   []string
 	Values map[string]string
+	// arrays records whether each key read from JSON was written as an array
+	// of lines, so saving keeps the form the file used
+	arrays map[string]bool
 }
 
-// UnmarshalJSON custom unmarshaler to preserve key order
+// UnmarshalJSON custom unmarshaler to preserve key order. Values are either
+// strings or arrays of lines, which are joined with newlines.
 func (om *OrderedMap) UnmarshalJSON(data []byte) error {
-	// First, unmarshal into a map to get values
-	values := make(map[string]string)
-	if err := json.Unmarshal(data, &values); err != nil {
-		return err
-	}
-
-	// Parse again to extract key order
-	var rawMap map[string]json.RawMessage
-	if err := json.Unmarshal(data, &rawMap); err != nil {
-		return err
-	}
-
-	// Unmarshal a third time using decoder to preserve order
 	dec := json.NewDecoder(bytes.NewReader(data))
 
 	// Read opening brace
@@ -50,6 +53,8 @@ func (om *OrderedMap) UnmarshalJSON(data []byte) error {
 	}
 
 	keys := []string{}
+	values := make(map[string]string)
+	arrays := make(map[string]bool)
 	for dec.More() {
 		// Read key
 		t, err := dec.Token()
@@ -57,21 +62,57 @@ func (om *OrderedMap) UnmarshalJSON(data []byte) error {
 			return err
 		}
 		key := t.(string)
-		keys = append(keys, key)
 
-		// Read value (skip it, we already have it in the map)
-		var value string
-		if err := dec.Decode(&value); err != nil {
+		// Read value
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
 			return err
 		}
+		value, isArray, err := decodeConfigValue(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		arrays[key] = isArray
+
+		// Later duplicates replace the value but keep the first position
+		if _, exists := values[key]; !exists {
+			keys = append(keys, key)
+		}
+		values[key] = value
 	}
 
 	om.Keys = keys
 	om.Values = values
+	om.arrays = arrays
 	return nil
 }
 
-// MarshalJSON custom marshaler
+// decodeConfigValue reads a string or an array of lines, telling which it was
+func decodeConfigValue(raw json.RawMessage) (string, bool, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, false, nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(raw, &lines); err != nil {
+		return "", false, fmt.Errorf("expected a string or an array of strings")
+	}
+	return strings.Join(lines, "\n"), true, nil
+}
+
+// writeAsArray returns true for values saved as arrays of lines: those read
+// as arrays, and new multi-line values so they stay readable in the file.
+// A multi-line value read as a string stays a string.
+func (om OrderedMap) writeAsArray(key string) bool {
+	if isArray, read := om.arrays[key]; read {
+		return isArray
+	}
+	return strings.Contains(om.Values[key], "\n")
+}
+
+// MarshalJSON custom marshaler. Values keep the form they were read in,
+// see writeAsArray.
 func (om OrderedMap) MarshalJSON() ([]byte, error) {
 	if om.Values == nil {
 		return []byte("{}"), nil
@@ -86,7 +127,12 @@ func (om OrderedMap) MarshalJSON() ([]byte, error) {
 		}
 
 		keyJSON, _ := json.Marshal(key)
-		valueJSON, _ := json.Marshal(om.Values[key])
+		var valueJSON []byte
+		if value := om.Values[key]; om.writeAsArray(key) {
+			valueJSON, _ = json.Marshal(strings.Split(value, "\n"))
+		} else {
+			valueJSON, _ = json.Marshal(value)
+		}
 
 		buf.Write(keyJSON)
 		buf.WriteString(":")
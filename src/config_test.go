@@ -0,0 +1,79 @@
+package src
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOrderedMapRoundTrip(t *testing.T) {
+	input := `{"div":"── Work ──","plain":"echo hi","escaped":"line one\nline two","array":["# Title","","body"],"div1":"── Misc ──","single":["one line"]}`
+
+	var first OrderedMap
+	if err := json.Unmarshal([]byte(input), &first); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := json.Marshal(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(saved) != input {
+		t.Errorf("saved\n%s\nwant\n%s", saved, input)
+	}
+
+	var second OrderedMap
+	if err := json.Unmarshal(saved, &second); err != nil {
+		t.Fatal(err)
+	}
+	wantKeys := []string{"div", "plain", "escaped", "array", "div1", "single"}
+	if !reflect.DeepEqual(second.Keys, wantKeys) {
+		t.Errorf("keys %v, want %v", second.Keys, wantKeys)
+	}
+	if !reflect.DeepEqual(second.Values, first.Values) {
+		t.Errorf("values %q, want %q", second.Values, first.Values)
+	}
+	if got := second.Values["array"]; got != "# Title\n\nbody" {
+		t.Errorf("array joined to %q", got)
+	}
+}
+
+func TestOrderedMapNewMultiLineValue(t *testing.T) {
+	var items OrderedMap
+	if err := json.Unmarshal([]byte(`{"old":"a"}`), &items); err != nil {
+		t.Fatal(err)
+	}
+	items.Keys = append(items.Keys, "new")
+	items.Values["new"] = "x\ny"
+	items.Values["old"] = "a\nb"
+
+	saved, err := json.Marshal(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"old":"a\nb","new":["x","y"]}`
+	if string(saved) != want {
+		t.Errorf("saved %s, want %s", saved, want)
+	}
+}
+
+func TestOrderedMapRejectsOtherValues(t *testing.T) {
+	for _, input := range []string{`{"a":1}`, `{"a":["x",2]}`, `{"a":{"b":"c"}}`, `["a"]`} {
+		var items OrderedMap
+		if err := json.Unmarshal([]byte(input), &items); err == nil {
+			t.Errorf("%s: expected an error", input)
+		} else if strings.Contains(input, `"a":`) && !strings.HasPrefix(err.Error(), "a:") {
+			t.Errorf("%s: error %q doesn't name the key", input, err)
+		}
+	}
+}
+
+func TestOrderedMapDuplicateKeys(t *testing.T) {
+	var items OrderedMap
+	if err := json.Unmarshal([]byte(`{"a":"1","b":"2","a":"3"}`), &items); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(items.Keys, []string{"a", "b"}) || items.Values["a"] != "3" {
+		t.Errorf("got %v %v", items.Keys, items.Values)
+	}
+}
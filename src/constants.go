@@ -4,8 +4,13 @@ const (
 	ExitSignal = "EXIT_SIGNAL"
 
 	// Directory and file names
-	AppDirName            = ".terminal-gameplay"
-	ConfigFileName        = "config.json"
-	OptionsFileName       = "options.json"
-	GoToFrequencyFileName = "goto_frequency.json"
+	AppDirName               = ".terminal-gameplay"
+	ConfigFileName           = "config.json"
+	OptionsFileName          = "options.json"
+	GoToFrequencyFileName    = "goto_frequency.json"
+	ClipboardHistoryFileName = "clipboard_history.json"
+	RepoCacheFileName        = "repos.json"
+	RunHistoryFileName       = "run_history.jsonl"
+	ThemesDirName            = "themes"
+	NotesDirName             = "notes"
 )
@@ -0,0 +1,165 @@
+package src
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Names of the goTo actions used by the goto_action and goto_actions options
+const (
+	CdActionName          = "cd"
+	TmuxWindowActionName  = "tmux-window"
+	TmuxSessionActionName = "tmux-session"
+	ShellActionName       = "shell"
+)
+
+var dirActionNames = map[SelectionAction]string{
+	OpenAction:        CdActionName,
+	TmuxWindowAction:  TmuxWindowActionName,
+	TmuxSessionAction: TmuxSessionActionName,
+	ShellAction:       ShellActionName,
+}
+
+// DirActionNames returns the goTo action names in the order the settings page cycles through them
+func DirActionNames() []string {
+	return []string{CdActionName, TmuxWindowActionName, TmuxSessionActionName, ShellActionName}
+}
+
+// parseDirAction returns the action with the given name
+func parseDirAction(name string) (SelectionAction, bool) {
+	for action, actionName := range dirActionNames {
+		if actionName == name {
+			return action, true
+		}
+	}
+	return OpenAction, false
+}
+
+// goToAction returns what Enter does with a goTo item: the action set for its
+// label in goto_actions, or else the goto_action option
+func goToAction(options *OptionsDTO, label string) SelectionAction {
+	if action, ok := parseDirAction(options.GoToActions[label]); ok {
+		return action
+	}
+	action, _ := parseDirAction(options.GoToAction)
+	return action
+}
+
+// modifierAction maps the modifiers held on a click to a goTo action
+func modifierAction(modifiers Modifiers) SelectionAction {
+	switch {
+	case modifiers.Alt:
+		return TmuxWindowAction
+	case modifiers.Ctrl:
+		return TmuxSessionAction
+	case modifiers.Shift:
+		return ShellAction
+	default:
+		return OpenAction
+	}
+}
+
+// tmuxSessionName turns a label into a session name tmux accepts, which
+// can't hold dots or colons
+func tmuxSessionName(label string) string {
+	name := strings.TrimSpace(strings.NewReplacer(".", "_", ":", "_").Replace(label))
+	if name == "" {
+		return "tg"
+	}
+	return name
+}
+
+// DirActionRunner opens a goTo directory other than by a cd in the calling shell
+type DirActionRunner interface {
+	Run(action SelectionAction, name, dir string) error
+}
+
+// ExecDirActionRunner runs tmux or a shell once the TUI has exited
+type ExecDirActionRunner struct {
+	// Tmux is the tmux binary
+	Tmux string
+	// InTmux is true when tg runs inside a tmux client
+	InTmux bool
+	// Shell is started by ShellAction
+	Shell  string
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+func NewExecDirActionRunner() *ExecDirActionRunner {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	return &ExecDirActionRunner{
+		Tmux:   "tmux",
+		InTmux: os.Getenv("TMUX") != "",
+		Shell:  shell,
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+}
+
+// Run opens dir with action, naming the tmux window or session name
+func (r *ExecDirActionRunner) Run(action SelectionAction, name, dir string) error {
+	switch action {
+	case TmuxWindowAction:
+		if !r.InTmux {
+			return fmt.Errorf("tg isn't running inside tmux, there is no session to add a window to")
+		}
+		return r.tmux("new-window", "-c", dir, "-n", name)
+
+	case TmuxSessionAction:
+		session := tmuxSessionName(name)
+		if !r.InTmux {
+			// Attach from this terminal, creating the session if needed
+			return r.attach(dir, r.Tmux, "new-session", "-A", "-s", session, "-c", dir)
+		}
+		if err := r.tmux("has-session", "-t", "="+session); err != nil {
+			if err := r.tmux("new-session", "-d", "-s", session, "-c", dir); err != nil {
+				return err
+			}
+		}
+		return r.tmux("switch-client", "-t", "="+session)
+
+	case ShellAction:
+		return r.attach(dir, r.Shell)
+
+	default:
+		return fmt.Errorf("Run -> unknown action %d", action)
+	}
+}
+
+// tmux runs a tmux command that doesn't need the terminal
+func (r *ExecDirActionRunner) tmux(args ...string) error {
+	out, err := exec.Command(r.Tmux, args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("tmux %s: %s", args[0], msg)
+		}
+		return fmt.Errorf("tmux %s: %v", args[0], err)
+	}
+	return nil
+}
+
+// attach runs a command in dir on the terminal and waits for it to exit
+func (r *ExecDirActionRunner) attach(dir, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdin = r.Stdin
+	cmd.Stdout = r.Stdout
+	cmd.Stderr = r.Stderr
+	if err := cmd.Run(); err != nil {
+		// The exit status of an interactive shell is the last command's, not a failure
+		if _, exited := err.(*exec.ExitError); exited && name == r.Shell {
+			return nil
+		}
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
+}
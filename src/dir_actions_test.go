@@ -0,0 +1,134 @@
+//go:build !windows
+
+package src
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeTmux puts a tmux on PATH that logs each call, one line of arguments
+// joined by |, and fails has-session unless the session exists
+func fakeTmux(t *testing.T, sessionExists bool) (string, string) {
+	t.Helper()
+	bin := t.TempDir()
+	log := filepath.Join(t.TempDir(), "calls")
+
+	hasSession := "1"
+	if sessionExists {
+		hasSession = "0"
+	}
+	script := `#!/bin/sh
+line=""
+for arg in "$@"; do line="$line|$arg"; done
+echo "${line#|}" >> "` + log + `"
+if [ "$1" = has-session ]; then exit ` + hasSession + `; fi
+exit 0
+`
+	writeScript(t, filepath.Join(bin, "tmux"), script)
+	// A shell that logs the directory it started in
+	writeScript(t, filepath.Join(bin, "fake-shell"), `#!/bin/sh
+echo "shell|$PWD|$#" >> "`+log+`"
+`)
+
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return filepath.Join(bin, "fake-shell"), log
+}
+
+func writeScript(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readCalls(t *testing.T, log string) []string {
+	t.Helper()
+	content, err := os.ReadFile(log)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+}
+
+func assertCalls(t *testing.T, got, want []string) {
+	t.Helper()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("tmux calls:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func newTestRunner(shell string, inTmux bool) *ExecDirActionRunner {
+	return &ExecDirActionRunner{Tmux: "tmux", InTmux: inTmux, Shell: shell}
+}
+
+func TestRunTmuxWindow(t *testing.T) {
+	shell, log := fakeTmux(t, false)
+	dir := t.TempDir()
+
+	if err := newTestRunner(shell, true).Run(TmuxWindowAction, "api", dir); err != nil {
+		t.Fatal(err)
+	}
+	assertCalls(t, readCalls(t, log), []string{"new-window|-c|" + dir + "|-n|api"})
+}
+
+func TestRunTmuxWindowOutsideTmux(t *testing.T) {
+	shell, log := fakeTmux(t, false)
+
+	if err := newTestRunner(shell, false).Run(TmuxWindowAction, "api", t.TempDir()); err == nil {
+		t.Error("expected an error outside tmux")
+	}
+	assertCalls(t, readCalls(t, log), nil)
+}
+
+func TestRunTmuxNewSession(t *testing.T) {
+	shell, log := fakeTmux(t, false)
+	dir := t.TempDir()
+
+	if err := newTestRunner(shell, true).Run(TmuxSessionAction, "my.api:v2", dir); err != nil {
+		t.Fatal(err)
+	}
+	assertCalls(t, readCalls(t, log), []string{
+		"has-session|-t|=my_api_v2",
+		"new-session|-d|-s|my_api_v2|-c|" + dir,
+		"switch-client|-t|=my_api_v2",
+	})
+}
+
+func TestRunTmuxExistingSession(t *testing.T) {
+	shell, log := fakeTmux(t, true)
+	dir := t.TempDir()
+
+	if err := newTestRunner(shell, true).Run(TmuxSessionAction, "api", dir); err != nil {
+		t.Fatal(err)
+	}
+	assertCalls(t, readCalls(t, log), []string{
+		"has-session|-t|=api",
+		"switch-client|-t|=api",
+	})
+}
+
+func TestRunTmuxSessionOutsideTmux(t *testing.T) {
+	shell, log := fakeTmux(t, false)
+	dir := t.TempDir()
+
+	if err := newTestRunner(shell, false).Run(TmuxSessionAction, "api", dir); err != nil {
+		t.Fatal(err)
+	}
+	assertCalls(t, readCalls(t, log), []string{"new-session|-A|-s|api|-c|" + dir})
+}
+
+func TestRunShell(t *testing.T) {
+	shell, log := fakeTmux(t, false)
+	dir := t.TempDir()
+
+	if err := newTestRunner(shell, true).Run(ShellAction, "api", dir); err != nil {
+		t.Fatal(err)
+	}
+	assertCalls(t, readCalls(t, log), []string{"shell|" + dir + "|0"})
+}
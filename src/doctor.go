@@ -0,0 +1,142 @@
+package src
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// tg doctor can wait longer than the TUI, nothing is on screen meanwhile
+const doctorTimeout = 5 * time.Second
+
+const doctorUsage = "Usage: tg doctor [--prune]"
+
+// Doctor checks every goTo entry and lists those whose directory is gone.
+// With --prune it removes them along with frequency data nothing uses anymore.
+func (r *Runner) Doctor(args []string) {
+	prune := false
+	for _, arg := range args {
+		if arg != "--prune" {
+			r.utils.ExitWithError(fmt.Sprintf("Unknown argument %q\n\n%s", arg, doctorUsage))
+		}
+		prune = true
+	}
+
+	_, config, goToFrequency := r.load()
+	styles := DefaultStyles()
+
+	// Check goTo targets and visited directories in one go
+	paths := []string{}
+	targets := map[string]string{}
+	for _, key := range config.GoTo.Keys {
+		if isDividerKey(key) || isGlobValue(config.GoTo.Values[key]) {
+			continue
+		}
+		if path, ok := goToTarget(config.GoTo.Values[key]); ok {
+			targets[key] = path
+			paths = append(paths, path)
+		}
+	}
+	for dir := range goToFrequency.Visits {
+		paths = append(paths, dir)
+	}
+	statuses := CheckPaths(r.fileManager, paths, doctorTimeout)
+
+	var missing, unchecked, skipped []string
+	for _, key := range config.GoTo.Keys {
+		if isDividerKey(key) || isGlobValue(config.GoTo.Values[key]) {
+			continue
+		}
+		path, ok := targets[key]
+		switch {
+		case !ok:
+			skipped = append(skipped, key)
+		case statuses[path] == PathMissing:
+			missing = append(missing, key)
+		case statuses[path] == PathUnchecked:
+			unchecked = append(unchecked, key)
+		}
+	}
+
+	staleFrequencies := []string{}
+	for label := range goToFrequency.Frequencies {
+		if _, ok := goToValue(config, label); !ok {
+			staleFrequencies = append(staleFrequencies, label)
+		}
+	}
+	staleVisits := []string{}
+	for dir := range goToFrequency.Visits {
+		if statuses[dir] == PathMissing {
+			staleVisits = append(staleVisits, dir)
+		}
+	}
+
+	fmt.Println(styles.Text(fmt.Sprintf("Checked %d goTo entries", len(targets)+len(skipped)+len(config.GoToGlobs)), styles.TitleColor))
+	fmt.Println()
+	for _, key := range missing {
+		fmt.Printf("  %s %-24s %s\n", styles.Text("✗", styles.ErrorColor), key, config.GoTo.Values[key])
+	}
+	for _, key := range unchecked {
+		fmt.Printf("  %s %-24s %s %s\n", styles.Text("?", styles.FooterColor), key, config.GoTo.Values[key],
+			styles.Text(fmt.Sprintf("(no answer in %s)", doctorTimeout), styles.MutedTitleColor))
+	}
+	for _, key := range skipped {
+		reason := "(unresolved reference, not checked)"
+		if strings.Contains(config.GoTo.Values[key], cmdRefPrefix) {
+			reason = "(runs a command, not checked)"
+		}
+		fmt.Printf("  %s %-24s %s %s\n", styles.Text("-", styles.MutedTitleColor), key, config.GoTo.Values[key],
+			styles.Text(reason, styles.MutedTitleColor))
+	}
+	for _, glob := range config.GoToGlobs {
+		fmt.Printf("  %s %-24s %s %s\n", styles.Text("-", styles.MutedTitleColor), glob.Label, glob.Pattern,
+			styles.Text(fmt.Sprintf("(pattern, %d matches)", len(glob.Matches)), styles.MutedTitleColor))
+	}
+	if len(staleFrequencies) > 0 || len(staleVisits) > 0 {
+		fmt.Println()
+		fmt.Println(styles.Text(fmt.Sprintf("%d frequency counts for removed entries, %d visited directories that are gone",
+			len(staleFrequencies), len(staleVisits)), styles.FooterColor))
+	}
+
+	dead := len(missing) + len(staleFrequencies) + len(staleVisits)
+	if dead == 0 {
+		fmt.Println(styles.Text("✓ All goTo entries point to existing directories", styles.AquamarineColor))
+		return
+	}
+	if !prune {
+		if len(missing) > 0 {
+			fmt.Println()
+			fmt.Println(styles.Text(fmt.Sprintf("%d goTo entries point to missing directories", len(missing)), styles.ErrorColor))
+		}
+		fmt.Println(styles.Text("Run tg doctor --prune to remove them", styles.FooterColor))
+		return
+	}
+
+	removed := map[string]bool{}
+	for _, key := range missing {
+		removed[key] = true
+		delete(config.GoTo.Values, key)
+	}
+	keys := []string{}
+	for _, key := range config.GoTo.Keys {
+		if !removed[key] {
+			keys = append(keys, key)
+		}
+	}
+	config.GoTo.Keys = keys
+
+	if len(missing) > 0 {
+		r.saveConfig(config)
+	}
+	r.updateGoToFrequency(func(goToFrequency *GoToFrequencyDTO) {
+		for _, label := range append(missing, staleFrequencies...) {
+			delete(goToFrequency.Frequencies, label)
+		}
+		for _, dir := range staleVisits {
+			delete(goToFrequency.Visits, dir)
+		}
+	})
+	fmt.Println()
+	fmt.Println(styles.Text(fmt.Sprintf("✓ Removed %d goTo entries, %d frequency counts and %d visited directories",
+		len(missing), len(staleFrequencies), len(staleVisits)), styles.AquamarineColor))
+}
@@ -0,0 +1,231 @@
+package src
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Editor used when neither $VISUAL nor $EDITOR is set
+const defaultEditor = "vi"
+
+// valueEditedMsg carries the new value of a config item after the editor exits
+type valueEditedMsg struct {
+	page  PageType
+	key   string
+	value string
+	err   error
+}
+
+// configEditedMsg asks the view to reload the config after a file was edited
+type configEditedMsg struct {
+	config *ConfigDTO
+	err    error
+}
+
+// editorCommand builds the command that opens path in $VISUAL or $EDITOR.
+// The variables may hold arguments, e.g. "code --wait".
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		fields = []string{defaultEditor}
+	}
+	return exec.Command(fields[0], append(fields[1:], path)...)
+}
+
+// EditItem opens the value of an item in the editor. Note files are edited in
+// place, config values through a temporary file.
+func (a *Actions) EditItem(page PageType, item ListItem) tea.Cmd {
+	if item.Path != "" {
+		return a.editFile(item.Path)
+	}
+
+	file, err := os.CreateTemp("", "tg-*"+editorFileExt(page))
+	if err != nil {
+		return a.editFailed(fmt.Errorf("EditItem -> %v", err))
+	}
+	_, err = file.WriteString(item.D + "\n")
+	file.Close()
+	if err != nil {
+		os.Remove(file.Name())
+		return a.editFailed(fmt.Errorf("EditItem -> %v", err))
+	}
+
+	return tea.ExecProcess(editorCommand(file.Name()), func(err error) tea.Msg {
+		defer os.Remove(file.Name())
+		if err != nil {
+			return valueEditedMsg{err: fmt.Errorf("Editor failed: %v", err)}
+		}
+		content, err := a.fileManager.ReadFileContent(file.Name())
+		if err != nil {
+			return valueEditedMsg{err: fmt.Errorf("Failed to read edited value: %v", err)}
+		}
+		return valueEditedMsg{
+			page:  page,
+			key:   item.T,
+			value: strings.TrimRight(content, "\n"),
+		}
+	})
+}
+
+// EditConfig opens config.json in the editor and reloads it when the editor exits
+func (a *Actions) EditConfig() tea.Cmd {
+	return a.editFile(a.fileManager.(*FileManager).ConfigPath)
+}
+
+// EditOptions opens options.json in the editor. Live reload picks up the
+// changes, like edits made outside tg.
+func (a *Actions) EditOptions() tea.Cmd {
+	return tea.ExecProcess(editorCommand(a.fileManager.(*FileManager).OptionsPath), func(err error) tea.Msg {
+		if err != nil {
+			return actionResultMsg{err: fmt.Errorf("Editor failed: %v", err)}
+		}
+		return actionResultMsg{}
+	})
+}
+
+// editFile opens a file in the editor, then reloads the config
+func (a *Actions) editFile(path string) tea.Cmd {
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		if err != nil {
+			return configEditedMsg{err: fmt.Errorf("Editor failed: %v", err)}
+		}
+		config, err := a.LoadConfig()
+		return configEditedMsg{config: config, err: err}
+	})
+}
+
+// editFailed reports an error that happened before the editor could start
+func (a *Actions) editFailed(err error) tea.Cmd {
+	return func() tea.Msg {
+		return valueEditedMsg{err: err}
+	}
+}
+
+// isEditablePage returns true for pages whose items come from config.json or note files
+func isEditablePage(page PageType) bool {
+	switch page {
+	case GoToPage, FrequentPage, CommandsPage, NotesPage:
+		return true
+	default:
+		return false
+	}
+}
+
+// editorFileExt gives the temporary file an extension so editors pick a
+// fitting syntax
+func editorFileExt(page PageType) string {
+	switch page {
+	case CommandsPage:
+		return ".sh"
+	case NotesPage:
+		return ".md"
+	default:
+		return ".txt"
+	}
+}
+
+// editCurrent opens the selected item in the editor. Settings are edited in
+// place and the clipboard history is read-only.
+func (m MultiPageViewModel) editCurrent() (tea.Model, tea.Cmd) {
+	item, ok := m.selectedItem()
+	if !ok || !isEditablePage(m.currentPage) || m.actions == nil {
+		return m, nil
+	}
+	if IsSecret(item.D) {
+		m.errorMessage = fmt.Sprintf("Press %s to make the note plain before editing it", helpKeys(m.keys.Secret))
+		return m, nil
+	}
+	if item.Suggestion {
+		m.errorMessage = fmt.Sprintf("Press %s to save the suggestion to goTo before editing it", helpKeys(m.keys.Promote))
+		return m, nil
+	}
+	if item.Generated {
+		pattern, _, _ := strings.Cut(item.T, "/")
+		m.errorMessage = fmt.Sprintf("%s comes from the pattern %q, edit that entry instead", item.T, pattern)
+		return m, nil
+	}
+	return m, m.actions.EditItem(m.currentPage, item)
+}
+
+// setConfigValue stores an edited value, saves config.json and refreshes the lists
+func (m MultiPageViewModel) setConfigValue(msg valueEditedMsg) (tea.Model, tea.Cmd) {
+	var items *OrderedMap
+	switch msg.page {
+	case GoToPage, FrequentPage:
+		items = &m.config.GoTo
+		// A directory is a single line
+		msg.value = strings.TrimSpace(msg.value)
+	case CommandsPage:
+		items = &m.config.Commands
+	case NotesPage:
+		items = &m.config.Notes
+	}
+	if items == nil || items.Values == nil {
+		return m, nil
+	}
+	if _, ok := items.Values[msg.key]; !ok {
+		m.errorMessage = fmt.Sprintf("%s is no longer in config.json", msg.key)
+		return m, nil
+	}
+
+	items.Values[msg.key] = msg.value
+	m.setConfig(m.config)
+
+	if m.actions != nil {
+		if err := m.actions.SaveConfig(m.config); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to save config: %v", err)
+			return m, nil
+		}
+	}
+	m.errorMessage = ""
+	return m, nil
+}
+
+// setConfig replaces the config shown by the view, keeping the current page
+// and cursor where possible
+func (m *MultiPageViewModel) setConfig(config *ConfigDTO) {
+	selected, hadSelection := m.selectedItem()
+
+	m.config = config
+	m.goToList = buildGoToList(config)
+	m.commandList = ConfigItemsToListItems(config.Commands)
+	m.notesList = buildNotesList(config)
+	m.rebuildPages()
+	m.dirPreviews = map[string]*DirPreview{}
+	m.pathStatus = map[string]PathStatus{}
+	m.pathModTimes = map[string]time.Time{}
+
+	if m.searchMode {
+		m.updateFilteredList()
+	}
+
+	// Follow the selected item by its label, it may have moved
+	items := m.getActiveList()
+	if hadSelection {
+		for i, item := range items {
+			if !item.IsDiv && item.T == selected.T {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	if m.cursor >= len(items) {
+		m.cursor = len(items) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	for m.cursor < len(items) && items[m.cursor].IsDiv {
+		m.cursor++
+	}
+	m.clampViewport()
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 type FileManagerInterface interface {
 	CheckIfPathExists(path string) (bool, error)
+	GetPathModTime(path string) (time.Time, bool, error)
 	ReadFileContent(filePath string) (string, error)
 	WriteFileContent(filePath, content string) error
 	GetConfigContent() (string, error)
@@ -16,16 +19,31 @@ type FileManagerInterface interface {
 	WriteOptionsContent(content string) error
 	GetGoToFrequencyContent() (string, error)
 	WriteGoToFrequencyContent(content string) error
+	LockGoToFrequency() (func(), error)
+	GetClipboardHistoryContent() (string, error)
+	WriteClipboardHistoryContent(content string) error
+	GetRepoCacheContent() (string, error)
+	WriteRepoCacheContent(content string) error
+	GetRunHistoryContent() (string, error)
+	AppendRunHistoryContent(line string) error
+	WriteRunHistoryContent(content string) error
+	GetThemesContent() (map[string]string, error)
+	GetNoteFiles() ([]NoteFile, error)
 	BasicSetup() error
 	GetCurrentDirectoryName() (string, error)
 }
 
 type FileManager struct {
-	HomeDir           string
-	AppDir            string
-	ConfigPath        string
-	OptionsPath       string
-	GoToFrequencyPath string
+	HomeDir              string
+	AppDir               string
+	ConfigPath           string
+	OptionsPath          string
+	GoToFrequencyPath    string
+	ClipboardHistoryPath string
+	RepoCachePath        string
+	RunHistoryPath       string
+	ThemesDir            string
+	NotesDir             string
 }
 
 func NewFileManager() (*FileManager, error) {
@@ -38,13 +56,23 @@ func NewFileManager() (*FileManager, error) {
 	configPath := filepath.Join(appDir, ConfigFileName)
 	optionsPath := filepath.Join(appDir, OptionsFileName)
 	goToFrequencyPath := filepath.Join(appDir, GoToFrequencyFileName)
+	clipboardHistoryPath := filepath.Join(appDir, ClipboardHistoryFileName)
+	repoCachePath := filepath.Join(appDir, RepoCacheFileName)
+	runHistoryPath := filepath.Join(appDir, RunHistoryFileName)
+	themesDir := filepath.Join(appDir, ThemesDirName)
+	notesDir := filepath.Join(appDir, NotesDirName)
 
 	return &FileManager{
-		HomeDir:          homeDir,
-		AppDir:           appDir,
-		ConfigPath:       configPath,
-		OptionsPath:      optionsPath,
-		GoToFrequencyPath: goToFrequencyPath,
+		HomeDir:              homeDir,
+		AppDir:               appDir,
+		ConfigPath:           configPath,
+		OptionsPath:          optionsPath,
+		GoToFrequencyPath:    goToFrequencyPath,
+		ClipboardHistoryPath: clipboardHistoryPath,
+		RepoCachePath:        repoCachePath,
+		RunHistoryPath:       runHistoryPath,
+		ThemesDir:            themesDir,
+		NotesDir:             notesDir,
 	}, nil
 }
 
@@ -69,6 +97,18 @@ func (m *FileManager) CheckIfPathExists(path string) (bool, error) {
 	return false, fmt.Errorf("CheckIfPathExists -> %v", err)
 }
 
+// GetPathModTime returns when path last changed, false when it doesn't exist
+func (m *FileManager) GetPathModTime(path string) (time.Time, bool, error) {
+	info, err := os.Stat(path)
+	if err == nil {
+		return info.ModTime(), true, nil
+	}
+	if os.IsNotExist(err) {
+		return time.Time{}, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("GetPathModTime -> %v", err)
+}
+
 func (m *FileManager) checkAndCreateFile(filePath string) error {
 	exists, err := m.CheckIfPathExists(filePath)
 	if err != nil {
@@ -91,11 +131,37 @@ func (m *FileManager) ReadFileContent(filePath string) (string, error) {
 	return string(data), nil
 }
 
+// WriteFileContent replaces the file through a temporary file, so readers such
+// as the live reload or a concurrent tg track never see it half written
 func (m *FileManager) WriteFileContent(filePath, content string) error {
-	err := os.WriteFile(filePath, []byte(content), 0644)
+	// Keep symlinked files, e.g. from a dotfiles repo, pointing where they did
+	if target, err := filepath.EvalSymlinks(filePath); err == nil {
+		filePath = target
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*")
 	if err != nil {
 		return fmt.Errorf("WriteFileContent -> %s %v", filePath, err)
 	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("WriteFileContent -> %s %v", filePath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("WriteFileContent -> %s %v", filePath, err)
+	}
+	// Keep the mode of the file replaced. New files stay private, as they
+	// may hold copied secrets, the temporary file already is 0600.
+	if info, err := os.Stat(filePath); err == nil {
+		if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("WriteFileContent -> %s %v", filePath, err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return fmt.Errorf("WriteFileContent -> %s %v", filePath, err)
+	}
 	return nil
 }
 
@@ -147,6 +213,158 @@ func (m *FileManager) WriteGoToFrequencyContent(content string) error {
 	return nil
 }
 
+// LockGoToFrequency takes the lock held around every read-modify-write of
+// goto_frequency.json, which the shell hook updates in the background. The
+// returned function releases it.
+func (m *FileManager) LockGoToFrequency() (func(), error) {
+	lock, err := os.OpenFile(filepath.Join(m.AppDir, goToFrequencyLockFileName), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("LockGoToFrequency -> %v", err)
+	}
+	if err := lockFile(lock); err != nil {
+		lock.Close()
+		return nil, fmt.Errorf("LockGoToFrequency -> %v", err)
+	}
+	return func() { lock.Close() }, nil
+}
+
+func (m *FileManager) GetClipboardHistoryContent() (string, error) {
+	str, err := m.ReadFileContent(m.ClipboardHistoryPath)
+	if err != nil {
+		return "", fmt.Errorf("GetClipboardHistoryContent -> %s %v", m.ClipboardHistoryPath, err)
+	}
+	return str, nil
+}
+
+func (m *FileManager) WriteClipboardHistoryContent(content string) error {
+	err := m.WriteFileContent(m.ClipboardHistoryPath, content)
+	if err != nil {
+		return fmt.Errorf("WriteClipboardHistoryContent -> %s: %v", m.ClipboardHistoryPath, err)
+	}
+	// Copied values may be secrets, also when an older tg made the file readable
+	if err := os.Chmod(m.ClipboardHistoryPath, 0600); err != nil {
+		return fmt.Errorf("WriteClipboardHistoryContent -> %s: %v", m.ClipboardHistoryPath, err)
+	}
+	return nil
+}
+
+// GetRepoCacheContent reads the repository scan cache, empty before the first scan
+func (m *FileManager) GetRepoCacheContent() (string, error) {
+	exists, err := m.CheckIfPathExists(m.RepoCachePath)
+	if err != nil || !exists {
+		return "", err
+	}
+	str, err := m.ReadFileContent(m.RepoCachePath)
+	if err != nil {
+		return "", fmt.Errorf("GetRepoCacheContent -> %s %v", m.RepoCachePath, err)
+	}
+	return str, nil
+}
+
+func (m *FileManager) WriteRepoCacheContent(content string) error {
+	err := m.WriteFileContent(m.RepoCachePath, content)
+	if err != nil {
+		return fmt.Errorf("WriteRepoCacheContent -> %s: %v", m.RepoCachePath, err)
+	}
+	return nil
+}
+
+// GetRunHistoryContent reads the run history, one JSON record per line,
+// empty before the first run
+func (m *FileManager) GetRunHistoryContent() (string, error) {
+	exists, err := m.CheckIfPathExists(m.RunHistoryPath)
+	if err != nil || !exists {
+		return "", err
+	}
+	str, err := m.ReadFileContent(m.RunHistoryPath)
+	if err != nil {
+		return "", fmt.Errorf("GetRunHistoryContent -> %s %v", m.RunHistoryPath, err)
+	}
+	return str, nil
+}
+
+// AppendRunHistoryContent adds a line to the run history without rewriting it.
+// Commands and their output may hold secrets, so a new file is private
+func (m *FileManager) AppendRunHistoryContent(line string) error {
+	file, err := os.OpenFile(m.RunHistoryPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("AppendRunHistoryContent -> %s: %v", m.RunHistoryPath, err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(line + "\n"); err != nil {
+		return fmt.Errorf("AppendRunHistoryContent -> %s: %v", m.RunHistoryPath, err)
+	}
+	return nil
+}
+
+func (m *FileManager) WriteRunHistoryContent(content string) error {
+	err := m.WriteFileContent(m.RunHistoryPath, content)
+	if err != nil {
+		return fmt.Errorf("WriteRunHistoryContent -> %s: %v", m.RunHistoryPath, err)
+	}
+	return nil
+}
+
+// GetThemesContent returns the content of each theme file in the themes
+// directory, keyed by theme name (the file name without .json)
+func (m *FileManager) GetThemesContent() (map[string]string, error) {
+	themes := map[string]string{}
+
+	entries, err := os.ReadDir(m.ThemesDir)
+	if os.IsNotExist(err) {
+		return themes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetThemesContent -> %s %v", m.ThemesDir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		content, err := m.ReadFileContent(filepath.Join(m.ThemesDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("GetThemesContent -> %v", err)
+		}
+		themes[strings.TrimSuffix(entry.Name(), ".json")] = content
+	}
+
+	return themes, nil
+}
+
+// GetNoteFiles reads the Markdown and text files in the notes directory,
+// sorted by name. Each file is one note labeled by its name.
+func (m *FileManager) GetNoteFiles() ([]NoteFile, error) {
+	notes := []NoteFile{}
+
+	entries, err := os.ReadDir(m.NotesDir)
+	if os.IsNotExist(err) {
+		return notes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetNoteFiles -> %s %v", m.NotesDir, err)
+	}
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".md" && ext != ".txt") {
+			continue
+		}
+		path := filepath.Join(m.NotesDir, entry.Name())
+		content, err := m.ReadFileContent(path)
+		if err != nil {
+			return nil, fmt.Errorf("GetNoteFiles -> %v", err)
+		}
+		notes = append(notes, NoteFile{
+			Label:   strings.TrimSuffix(entry.Name(), ext),
+			Path:    path,
+			Content: strings.TrimRight(content, "\n"),
+		})
+	}
+
+	return notes, nil
+}
+
 func (m *FileManager) BasicSetup() error {
 	if err := m.ensureAppDir(); err != nil {
 		return err
@@ -156,6 +374,7 @@ func (m *FileManager) BasicSetup() error {
 		m.ConfigPath,
 		m.OptionsPath,
 		m.GoToFrequencyPath,
+		m.ClipboardHistoryPath,
 	}
 
 	for _, file := range files {
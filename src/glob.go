@@ -0,0 +1,280 @@
+package src
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// Levels below the fixed part of a pattern searched by default
+	defaultGlobMaxDepth = 3
+	// Most directories a single pattern expands to
+	globMaxMatches = 500
+)
+
+// GoToGlob is a goTo entry whose value is a pattern, expanded into one item
+// per matching directory when the config is loaded
+type GoToGlob struct {
+	Label   string
+	Pattern string
+	// Matches are the matching directories labeled relative to the pattern
+	Matches []ConfigItem
+}
+
+// isGlobValue returns true for goTo values holding a pattern such as
+// ~/workspace/* on their first line. A [ alone doesn't make a pattern, it is
+// a valid character in directory names.
+func isGlobValue(value string) bool {
+	if strings.Contains(value, cmdRefPrefix) {
+		return false
+	}
+	first, _, _ := strings.Cut(value, "\n")
+	return strings.ContainsAny(first, "*?")
+}
+
+// parseGlobValue splits a pattern value into the patterns to include and the
+// ones to exclude, written on their own lines starting with !
+func parseGlobValue(value string) ([]string, []string) {
+	var include, exclude []string
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "!"):
+			exclude = append(exclude, strings.TrimSpace(line[1:]))
+		default:
+			include = append(include, line)
+		}
+	}
+	return include, exclude
+}
+
+// expandGoToGlobs expands every pattern entry of goTo into the directories it
+// matches, at most maxDepth levels below the fixed part of the pattern
+func expandGoToGlobs(config *ConfigDTO, maxDepth int) {
+	config.GoToGlobs = nil
+
+	for _, key := range config.GoTo.Keys {
+		value := config.GoTo.Values[key]
+		if isDividerKey(key) || !isGlobValue(value) {
+			continue
+		}
+
+		include, exclude := parseGlobValue(value)
+		exclude = expandPatterns(exclude)
+
+		seen := map[string]bool{}
+		matches := []ConfigItem{}
+		for _, pattern := range expandPatterns(include) {
+			base, dirs := expandGlob(pattern, exclude, maxDepth)
+			for _, dir := range dirs {
+				if seen[dir] || len(matches) == globMaxMatches {
+					continue
+				}
+				seen[dir] = true
+				rel, err := filepath.Rel(base, dir)
+				if err != nil {
+					rel = filepath.Base(dir)
+				}
+				matches = append(matches, ConfigItem{
+					Label: key + "/" + filepath.ToSlash(rel),
+					Value: abbreviateHome(dir),
+				})
+			}
+		}
+
+		config.GoToGlobs = append(config.GoToGlobs, GoToGlob{
+			Label:   key,
+			Pattern: strings.Join(include, ", "),
+			Matches: matches,
+		})
+	}
+}
+
+// expandPatterns resolves the ${env:NAME} references of patterns and expands ~
+// and $VAR around them. A pattern naming an unset variable is dropped rather
+// than searched from the root.
+func expandPatterns(patterns []string) []string {
+	expanded := []string{}
+	for _, pattern := range patterns {
+		if path, err := expandPathReferences(pattern, false); err == nil {
+			expanded = append(expanded, path)
+		}
+	}
+	return expanded
+}
+
+// expandGlob returns the fixed directory a pattern starts from and the
+// directories matching it. * and ? don't match hidden names unless the
+// pattern segment starts with a dot, like in the shell, and ** matches any
+// number of directories.
+func expandGlob(pattern string, exclude []string, maxDepth int) (string, []string) {
+	segments := strings.Split(filepath.Clean(pattern), string(filepath.Separator))
+
+	fixed := 0
+	for fixed < len(segments) && !strings.ContainsAny(segments[fixed], "*?[") {
+		fixed++
+	}
+	base := strings.Join(segments[:fixed], string(filepath.Separator))
+	if base == "" {
+		base = string(filepath.Separator)
+	}
+
+	matches := []string{}
+	var walk func(dir string, segments []string, depth int)
+	walk = func(dir string, segments []string, depth int) {
+		if len(matches) >= globMaxMatches {
+			return
+		}
+		if len(segments) == 0 {
+			if dir != base {
+				matches = append(matches, dir)
+			}
+			return
+		}
+
+		segment := segments[0]
+		if segment == "**" {
+			// ** matches no directory at all too
+			walk(dir, segments[1:], depth)
+		}
+		if depth >= maxDepth {
+			return
+		}
+		for _, name := range subdirectories(dir) {
+			child := filepath.Join(dir, name)
+			if isExcluded(child, exclude) {
+				continue
+			}
+			if segment == "**" {
+				if !strings.HasPrefix(name, ".") {
+					walk(child, segments, depth+1)
+				}
+				continue
+			}
+			if strings.HasPrefix(name, ".") && !strings.HasPrefix(segment, ".") {
+				continue
+			}
+			if ok, _ := filepath.Match(segment, name); ok {
+				walk(child, segments[1:], depth+1)
+			}
+		}
+	}
+	walk(base, segments[fixed:], 0)
+
+	sort.Strings(matches)
+	return base, dedupe(matches)
+}
+
+// subdirectories lists the directories in dir, following symlinks
+func subdirectories(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+			continue
+		}
+		if entry.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(filepath.Join(dir, entry.Name())); err == nil && info.IsDir() {
+				names = append(names, entry.Name())
+			}
+		}
+	}
+	return names
+}
+
+// isExcluded matches a directory against exclusion patterns. Patterns with a
+// slash match the whole path, others only the directory name.
+func isExcluded(dir string, exclude []string) bool {
+	for _, pattern := range exclude {
+		target := filepath.Base(dir)
+		if strings.Contains(pattern, string(filepath.Separator)) {
+			target = dir
+		}
+		if ok, _ := filepath.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func dedupe(sorted []string) []string {
+	out := sorted[:0]
+	for i, s := range sorted {
+		if i == 0 || s != sorted[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// goToEntries lists the goTo entries of config.json in order, with the
+// directories a pattern matched in place of the pattern
+func goToEntries(config *ConfigDTO) []ConfigItem {
+	entries := []ConfigItem{}
+	for _, key := range config.GoTo.Keys {
+		value := config.GoTo.Values[key]
+		switch {
+		case isDividerKey(key):
+		case isGlobValue(value):
+			if glob, ok := findGoToGlob(config, key); ok {
+				entries = append(entries, glob.Matches...)
+			}
+		default:
+			entries = append(entries, ConfigItem{Label: key, Value: value})
+		}
+	}
+	return entries
+}
+
+// findGoToGlob returns the expansion of the pattern entry with the given label
+func findGoToGlob(config *ConfigDTO, label string) (GoToGlob, bool) {
+	for _, glob := range config.GoToGlobs {
+		if glob.Label == label {
+			return glob, true
+		}
+	}
+	return GoToGlob{}, false
+}
+
+// goToValue returns the value of a goTo entry by label, generated ones included
+func goToValue(config *ConfigDTO, label string) (string, bool) {
+	if value, ok := config.GoTo.Values[label]; ok && !isDividerKey(label) && !isGlobValue(value) {
+		return value, true
+	}
+	for _, glob := range config.GoToGlobs {
+		for _, match := range glob.Matches {
+			if match.Label == label {
+				return match.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// buildGoToList lists the goTo entries in config order, each pattern
+// replaced by a section with the directories it matched
+func buildGoToList(config *ConfigDTO) []ListItem {
+	items := []ListItem{}
+	for _, item := range ConfigItemsToListItems(config.GoTo) {
+		if item.IsDiv || !isGlobValue(item.D) {
+			items = append(items, item)
+			continue
+		}
+		glob, ok := findGoToGlob(config, item.T)
+		if !ok {
+			continue
+		}
+		items = append(items, ListItem{T: "div", D: "📂 " + glob.Pattern, IsDiv: true, Generated: true})
+		for _, match := range glob.Matches {
+			items = append(items, ListItem{T: match.Label, D: match.Value, Generated: true})
+		}
+	}
+	return items
+}
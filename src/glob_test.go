@@ -0,0 +1,142 @@
+package src
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// globTree creates directories below a temporary root and returns the root
+func globTree(t *testing.T, dirs ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, filepath.FromSlash(dir)), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// globMatches expands a single pattern entry and returns its labels and values
+func globMatches(t *testing.T, key, value string, maxDepth int) map[string]string {
+	t.Helper()
+	config := GetDefaultConfig()
+	config.GoTo.Keys = append(config.GoTo.Keys, key)
+	config.GoTo.Values[key] = value
+
+	expandGoToGlobs(config, maxDepth)
+	glob, ok := findGoToGlob(config, key)
+	if !ok {
+		t.Fatalf("no expansion for %s", key)
+	}
+	matches := map[string]string{}
+	for _, match := range glob.Matches {
+		matches[match.Label] = match.Value
+	}
+	return matches
+}
+
+func TestIsGlobValue(t *testing.T) {
+	tests := map[string]bool{
+		"~/workspace/*":         true,
+		"~/src/project-?":       true,
+		"~/src/**\n!vendor":     true,
+		"~/photos/[2024]":       false,
+		"~/work":                false,
+		"~/work\n*":             false,
+		"$(cmd: ls -d ~/src/*)": false,
+	}
+	for value, want := range tests {
+		if got := isGlobValue(value); got != want {
+			t.Errorf("isGlobValue(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestExpandGoToGlobs(t *testing.T) {
+	root := globTree(t,
+		"repos/api", "repos/web/src", "repos/.cache", "repos/node_modules/lib",
+		"deep/a/b/c/d", "deep/a/x",
+	)
+	t.Setenv("GLOB_TEST_ROOT", root)
+	join := func(rel string) string { return filepath.Join(root, filepath.FromSlash(rel)) }
+
+	tests := []struct {
+		name     string
+		value    string
+		maxDepth int
+		want     map[string]string
+	}{
+		{
+			name:     "one level, hidden skipped",
+			value:    root + "/repos/*",
+			maxDepth: defaultGlobMaxDepth,
+			want: map[string]string{
+				"k/api": join("repos/api"), "k/web": join("repos/web"), "k/node_modules": join("repos/node_modules"),
+			},
+		},
+		{
+			name:     "hidden asked for",
+			value:    root + "/repos/.*",
+			maxDepth: defaultGlobMaxDepth,
+			want:     map[string]string{"k/.cache": join("repos/.cache")},
+		},
+		{
+			name:     "excluded by name",
+			value:    root + "/repos/*\n!node_modules\n!web",
+			maxDepth: defaultGlobMaxDepth,
+			want:     map[string]string{"k/api": join("repos/api")},
+		},
+		{
+			name:     "excluded by path",
+			value:    "$GLOB_TEST_ROOT/repos/**\n!${env:GLOB_TEST_ROOT}/repos/node_modules",
+			maxDepth: defaultGlobMaxDepth,
+			want: map[string]string{
+				"k/api": join("repos/api"), "k/web": join("repos/web"), "k/web/src": join("repos/web/src"),
+			},
+		},
+		{
+			name:     "depth limit",
+			value:    root + "/deep/**",
+			maxDepth: 2,
+			want: map[string]string{
+				"k/a": join("deep/a"), "k/a/b": join("deep/a/b"), "k/a/x": join("deep/a/x"),
+			},
+		},
+		{
+			name:     "unset variable",
+			value:    "${env:GLOB_TEST_UNSET}/*",
+			maxDepth: defaultGlobMaxDepth,
+			want:     map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := globMatches(t, "k", tt.value, tt.maxDepth); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildGoToListKeepsPatternsInPlace(t *testing.T) {
+	root := globTree(t, "repos/api", "repos/web")
+
+	config := GetDefaultConfig()
+	config.GoTo = OrderedMap{
+		Keys:   []string{"first", "repos", "last"},
+		Values: map[string]string{"first": "/a", "repos": root + "/repos/*", "last": "/b"},
+	}
+	expandGoToGlobs(config, defaultGlobMaxDepth)
+
+	titles := []string{}
+	for _, item := range buildGoToList(config) {
+		titles = append(titles, item.T)
+	}
+	want := []string{"first", "div", "repos/api", "repos/web", "last"}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("got %v, want %v", titles, want)
+	}
+}
@@ -1,9 +1,24 @@
 package src
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// Most directories kept in Visits, the least visited are dropped past it
+	maxTrackedVisits = 500
+
+	// Held around every update of the frequency file, cd's in several shells
+	// and a running tg may write it at once. Named after tg track, which
+	// took it first.
+	goToFrequencyLockFileName = ".track.lock"
+)
 
 type GoToFrequencyDTO struct {
 	Frequencies map[string]int `json:"frequencies"`
+	// Visits counts cd's into each directory recorded by the shell hook, by absolute path
+	Visits map[string]int `json:"visits,omitempty"`
 }
 
 func GetDefaultGoToFrequency() *GoToFrequencyDTO {
@@ -12,6 +27,39 @@ func GetDefaultGoToFrequency() *GoToFrequencyDTO {
 	}
 }
 
+// UpdateGoToFrequency applies update to goto_frequency.json as it is on disk
+// and writes it back under the lock, so visits the shell hook records in the
+// meantime aren't lost. It returns what was saved.
+func UpdateGoToFrequency(fm FileManagerInterface, update func(*GoToFrequencyDTO)) (*GoToFrequencyDTO, error) {
+	unlock, err := fm.LockGoToFrequency()
+	if err != nil {
+		return nil, fmt.Errorf("UpdateGoToFrequency -> %v", err)
+	}
+	defer unlock()
+
+	content, err := fm.GetGoToFrequencyContent()
+	if err != nil {
+		return nil, fmt.Errorf("UpdateGoToFrequency -> %v", err)
+	}
+	goToFrequency := GetDefaultGoToFrequency()
+	if content != "" {
+		if goToFrequency, err = ParseJSONContent[GoToFrequencyDTO](content); err != nil {
+			return nil, fmt.Errorf("Failed to parse goto_frequency.json: %v", err)
+		}
+	}
+
+	update(goToFrequency)
+
+	jsonStr, err := ToJSON(goToFrequency)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateGoToFrequency -> %v", err)
+	}
+	if err := fm.WriteGoToFrequencyContent(jsonStr); err != nil {
+		return nil, fmt.Errorf("UpdateGoToFrequency -> %v", err)
+	}
+	return goToFrequency, nil
+}
+
 // IncrementGoTo increments the frequency count for a given goTo key
 func (wf *GoToFrequencyDTO) IncrementGoTo(key string) {
 	if wf.Frequencies == nil {
@@ -20,6 +68,16 @@ func (wf *GoToFrequencyDTO) IncrementGoTo(key string) {
 	wf.Frequencies[key]++
 }
 
+// SeedGoTo sets the frequency of a goTo key that has none yet
+func (wf *GoToFrequencyDTO) SeedGoTo(key string, frequency int) {
+	if wf.Frequencies == nil {
+		wf.Frequencies = make(map[string]int)
+	}
+	if wf.Frequencies[key] == 0 {
+		wf.Frequencies[key] = frequency
+	}
+}
+
 // GetTopGoToKeys returns goTo keys sorted by frequency (most frequent first)
 func (wf *GoToFrequencyDTO) GetTopGoToKeys() []string {
 	if len(wf.Frequencies) == 0 {
@@ -51,6 +109,34 @@ func (wf *GoToFrequencyDTO) GetTopGoToKeys() []string {
 	return keys
 }
 
+// TrackVisit counts a visit to dir, forgetting the least visited directories
+// once more than maxTrackedVisits are known
+func (wf *GoToFrequencyDTO) TrackVisit(dir string) {
+	if wf.Visits == nil {
+		wf.Visits = make(map[string]int)
+	}
+	wf.Visits[dir]++
+
+	if len(wf.Visits) <= maxTrackedVisits {
+		return
+	}
+	dirs := make([]string, 0, len(wf.Visits))
+	for d := range wf.Visits {
+		dirs = append(dirs, d)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if wf.Visits[dirs[i]] != wf.Visits[dirs[j]] {
+			return wf.Visits[dirs[i]] < wf.Visits[dirs[j]]
+		}
+		return dirs[i] < dirs[j]
+	})
+	for _, d := range dirs[:len(dirs)-maxTrackedVisits] {
+		if d != dir {
+			delete(wf.Visits, d)
+		}
+	}
+}
+
 // IsEmpty returns true if there are no recorded frequencies
 func (wf *GoToFrequencyDTO) IsEmpty() bool {
 	return len(wf.Frequencies) == 0
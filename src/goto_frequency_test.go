@@ -0,0 +1,47 @@
+//go:build !windows
+
+package src
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestUpdateGoToFrequencyKeepsConcurrentUpdates(t *testing.T) {
+	appDir := t.TempDir()
+	fm := &FileManager{AppDir: appDir, GoToFrequencyPath: filepath.Join(appDir, "goto_frequency.json")}
+	if err := fm.checkAndCreateFile(fm.GoToFrequencyPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// tg track from several shells and the TUI bumping a label at once
+	const updates = 20
+	var wg sync.WaitGroup
+	for i := range updates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := UpdateGoToFrequency(fm, func(goToFrequency *GoToFrequencyDTO) {
+				goToFrequency.TrackVisit(fmt.Sprintf("/srv/dir%d", i))
+				goToFrequency.IncrementGoTo("api")
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	saved, err := UpdateGoToFrequency(fm, func(*GoToFrequencyDTO) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Visits) != updates {
+		t.Errorf("got %d visited directories, want %d", len(saved.Visits), updates)
+	}
+	if saved.Frequencies["api"] != updates {
+		t.Errorf("api = %d, want %d", saved.Frequencies["api"], updates)
+	}
+}
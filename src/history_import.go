@@ -0,0 +1,454 @@
+package src
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Shells tg integrates with and reads the history of
+const (
+	BashShell = "bash"
+	ZshShell  = "zsh"
+	FishShell = "fish"
+)
+
+const (
+	// Commands run fewer times than this aren't offered
+	historyMinRuns = 2
+	// Most commands offered by tg import history
+	historyMaxCommands = 50
+	// Most words kept in a suggested label
+	historyLabelWords = 3
+)
+
+// Commands that are never worth saving, matched on the first word
+var historyNoise = map[string]bool{
+	"cd": true, "ls": true, "ll": true, "la": true, "clear": true, "exit": true,
+	"pwd": true, "history": true, "fg": true, "bg": true, "jobs": true, "tg": true,
+}
+
+var (
+	bashTimestamp = regexp.MustCompile(`^#\d+$`)
+	zshExtended   = regexp.MustCompile(`^: (\d+):\d+;`)
+)
+
+// HistoryEntry is one command read from a shell history
+type HistoryEntry struct {
+	Command string
+	RunAt   time.Time
+}
+
+// HistoryCommand is a command with how often and when it was last run
+type HistoryCommand struct {
+	Command string
+	Runs    int
+	LastRun time.Time
+}
+
+// ImportHistory offers the commands run most often as new Commands entries
+func (r *Runner) ImportHistory(args []string) {
+	importArgs, err := parseImportArgs(args)
+	if err == nil && importArgs.source != "" && !isHistoryShell(importArgs.source) {
+		err = fmt.Errorf("unknown shell %q, expected bash, zsh or fish", importArgs.source)
+	}
+	if err != nil {
+		r.utils.ExitWithError(fmt.Sprintf("%v\n\n%s", err, importUsage))
+	}
+
+	_, config, _ := r.load()
+	styles := DefaultStyles()
+
+	entries, err := ReadHistoryEntries(importArgs.source, importArgs.file)
+	if err != nil {
+		r.utils.HandleError(err, "Failed to read shell history")
+	}
+
+	commands := rankHistory(entries, config.Commands)
+	if len(commands) == 0 {
+		fmt.Println(styles.Text(fmt.Sprintf("No repeated commands to import from %d history entries", len(entries)), styles.FooterColor))
+		return
+	}
+
+	labels := commandLabels(commands, config.Commands)
+	items := make([]ListItem, len(commands))
+	for i, command := range commands {
+		items[i] = ListItem{T: labels[i], D: command.Command}
+	}
+
+	if importArgs.dryRun {
+		fmt.Println(styles.Text(fmt.Sprintf("Would offer %d commands from shell history:", len(items)), styles.TitleColor))
+		fmt.Println()
+		for i, item := range items {
+			runs := styles.Text(fmt.Sprintf("%4d×", commands[i].Runs), styles.MutedTitleColor)
+			fmt.Printf("  %s  %-24s %s\n", runs, item.T, strings.ReplaceAll(item.D, "\n", "⏎"))
+		}
+		return
+	}
+
+	chosen := r.viewBuilder.NewMultiSelectListView("Save shell history as Commands", items, 20, false)
+	if len(chosen) == 0 {
+		return
+	}
+
+	addDivider(&config.Commands, "📥 "+HistoryImport)
+	for _, item := range chosen {
+		config.Commands.Keys = append(config.Commands.Keys, item.T)
+		config.Commands.Values[item.T] = item.D
+	}
+
+	r.saveConfig(config)
+	fmt.Println(styles.Text(fmt.Sprintf("✓ Saved %d commands from shell history", len(chosen)), styles.AquamarineColor))
+}
+
+func isHistoryShell(shell string) bool {
+	return shell == BashShell || shell == ZshShell || shell == FishShell
+}
+
+// ReadHistoryEntries reads the history of shell, or of every shell that has
+// one when shell is empty. file replaces the default location; its format is
+// detected when no shell is given.
+func ReadHistoryEntries(shell, file string) ([]HistoryEntry, error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("ReadHistoryEntries -> %v", err)
+		}
+		if shell == "" {
+			shell = detectHistoryShell(data)
+		}
+		return parseHistory(shell, data), nil
+	}
+
+	shells := []string{BashShell, ZshShell, FishShell}
+	if shell != "" {
+		shells = []string{shell}
+	}
+
+	entries := []HistoryEntry{}
+	found := false
+	for _, shell := range shells {
+		path, err := defaultHistoryPath(shell)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ReadHistoryEntries -> %v", err)
+		}
+		found = true
+		entries = append(entries, parseHistory(shell, data)...)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("ReadHistoryEntries -> no %s history found", strings.Join(shells, ", "))
+	}
+	return entries, nil
+}
+
+// defaultHistoryPath returns where each shell keeps its history by default
+func defaultHistoryPath(shell string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("defaultHistoryPath -> %v", err)
+	}
+
+	switch shell {
+	case BashShell:
+		return filepath.Join(home, ".bash_history"), nil
+	case ZshShell:
+		if dir := os.Getenv("ZDOTDIR"); dir != "" {
+			return filepath.Join(dir, ".zsh_history"), nil
+		}
+		return filepath.Join(home, ".zsh_history"), nil
+	default:
+		dataDir := os.Getenv("XDG_DATA_HOME")
+		if dataDir == "" {
+			dataDir = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(dataDir, "fish", "fish_history"), nil
+	}
+}
+
+// detectHistoryShell guesses the format of a history file from its first line
+func detectHistoryShell(data []byte) string {
+	line, _, _ := bytes.Cut(bytes.TrimLeft(data, "\n"), []byte("\n"))
+	switch {
+	case bytes.HasPrefix(line, []byte("- cmd: ")):
+		return FishShell
+	case zshExtended.Match(line):
+		return ZshShell
+	default:
+		return BashShell
+	}
+}
+
+func parseHistory(shell string, data []byte) []HistoryEntry {
+	switch shell {
+	case ZshShell:
+		return parseZshHistory(data)
+	case FishShell:
+		return parseFishHistory(data)
+	default:
+		return parseBashHistory(data)
+	}
+}
+
+// parseBashHistory reads ~/.bash_history. With HISTTIMEFORMAT set each
+// command follows a #<unix time> line and may span several lines.
+func parseBashHistory(data []byte) []HistoryEntry {
+	lines := historyLines(data)
+
+	timestamped := false
+	for _, line := range lines {
+		if bashTimestamp.MatchString(line) {
+			timestamped = true
+			break
+		}
+	}
+
+	entries := []HistoryEntry{}
+	if !timestamped {
+		for _, line := range lines {
+			entries = append(entries, HistoryEntry{Command: line})
+		}
+		return entries
+	}
+
+	var current *HistoryEntry
+	for _, line := range lines {
+		if bashTimestamp.MatchString(line) {
+			if current != nil {
+				entries = append(entries, *current)
+			}
+			current = &HistoryEntry{RunAt: unixTime(line[1:])}
+			continue
+		}
+		if current == nil {
+			// Commands saved before timestamps were turned on
+			entries = append(entries, HistoryEntry{Command: line})
+			continue
+		}
+		if current.Command != "" {
+			current.Command += "\n"
+		}
+		current.Command += line
+	}
+	if current != nil {
+		entries = append(entries, *current)
+	}
+	return entries
+}
+
+// parseZshHistory reads plain or extended (": <time>:<duration>;command") zsh
+// history. Lines ending with a backslash continue on the next one.
+func parseZshHistory(data []byte) []HistoryEntry {
+	entries := []HistoryEntry{}
+	var current *HistoryEntry
+	for _, line := range historyLines(unmetafyZsh(data)) {
+		if current == nil {
+			current = &HistoryEntry{Command: line}
+			if match := zshExtended.FindStringSubmatch(line); match != nil {
+				current.RunAt = unixTime(match[1])
+				current.Command = line[len(match[0]):]
+			}
+		} else {
+			current.Command += "\n" + line
+		}
+
+		if strings.HasSuffix(current.Command, "\\") {
+			current.Command = strings.TrimSuffix(current.Command, "\\")
+			continue
+		}
+		entries = append(entries, *current)
+		current = nil
+	}
+	if current != nil {
+		entries = append(entries, *current)
+	}
+	return entries
+}
+
+// unmetafyZsh decodes the bytes zsh escapes in its history file: 0x83
+// followed by the byte xor 32
+func unmetafyZsh(data []byte) []byte {
+	if bytes.IndexByte(data, 0x83) < 0 {
+		return data
+	}
+	decoded := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] == 0x83 && i+1 < len(data) {
+			i++
+			decoded = append(decoded, data[i]^32)
+			continue
+		}
+		decoded = append(decoded, data[i])
+	}
+	return decoded
+}
+
+// parseFishHistory reads fish_history, a YAML-like list of "- cmd:" entries
+// followed by "  when:" and other indented fields
+func parseFishHistory(data []byte) []HistoryEntry {
+	entries := []HistoryEntry{}
+	for _, line := range historyLines(data) {
+		if command, ok := strings.CutPrefix(line, "- cmd: "); ok {
+			entries = append(entries, HistoryEntry{Command: unescapeFish(command)})
+			continue
+		}
+		if when, ok := strings.CutPrefix(line, "  when: "); ok && len(entries) > 0 {
+			entries[len(entries)-1].RunAt = unixTime(when)
+		}
+	}
+	return entries
+}
+
+// unescapeFish reverses the \\ and \n escapes fish writes in its history
+func unescapeFish(command string) string {
+	var b strings.Builder
+	for i := 0; i < len(command); i++ {
+		if command[i] == '\\' && i+1 < len(command) {
+			switch command[i+1] {
+			case 'n':
+				b.WriteByte('\n')
+				i++
+				continue
+			case '\\':
+				b.WriteByte('\\')
+				i++
+				continue
+			}
+		}
+		b.WriteByte(command[i])
+	}
+	return b.String()
+}
+
+// historyLines splits a history file into non-empty lines
+func historyLines(data []byte) []string {
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func unixTime(text string) time.Time {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+// rankHistory counts how often each command was run and returns the ones run
+// at least historyMinRuns times, most run first. Noise and commands already
+// saved are left out.
+func rankHistory(entries []HistoryEntry, saved OrderedMap) []HistoryCommand {
+	existing := map[string]bool{}
+	for _, value := range saved.Values {
+		existing[strings.TrimSpace(value)] = true
+	}
+
+	byCommand := map[string]*HistoryCommand{}
+	for _, entry := range entries {
+		command := strings.TrimSpace(entry.Command)
+		fields := strings.Fields(command)
+		if len(fields) == 0 || historyNoise[fields[0]] || existing[command] {
+			continue
+		}
+
+		ranked, ok := byCommand[command]
+		if !ok {
+			ranked = &HistoryCommand{Command: command}
+			byCommand[command] = ranked
+		}
+		ranked.Runs++
+		if entry.RunAt.After(ranked.LastRun) {
+			ranked.LastRun = entry.RunAt
+		}
+	}
+
+	commands := []HistoryCommand{}
+	for _, command := range byCommand {
+		if command.Runs >= historyMinRuns {
+			commands = append(commands, *command)
+		}
+	}
+
+	sort.Slice(commands, func(i, j int) bool {
+		if commands[i].Runs != commands[j].Runs {
+			return commands[i].Runs > commands[j].Runs
+		}
+		if !commands[i].LastRun.Equal(commands[j].LastRun) {
+			return commands[i].LastRun.After(commands[j].LastRun)
+		}
+		return commands[i].Command < commands[j].Command
+	})
+
+	if len(commands) > historyMaxCommands {
+		commands = commands[:historyMaxCommands]
+	}
+	return commands
+}
+
+// suggestCommandLabel names a command after its first words, skipping
+// variable assignments and sudo and stopping at flags, paths and operators
+func suggestCommandLabel(command string) string {
+	firstLine, _, _ := strings.Cut(command, "\n")
+	fields := strings.Fields(firstLine)
+
+	words := []string{}
+	for _, field := range fields {
+		if len(words) == 0 && (field == "sudo" || field == "env" || strings.Contains(field, "=")) {
+			continue
+		}
+		if len(words) > 0 && strings.ContainsAny(field, "-/=|&;<>$\"'`.~*") {
+			break
+		}
+		words = append(words, field)
+		if len(words) == historyLabelWords {
+			break
+		}
+	}
+
+	if len(words) == 0 && len(fields) > 0 {
+		return entryLabel(fields[0])
+	}
+	return entryLabel(strings.Join(words, " "))
+}
+
+// commandLabels suggests a label for each command, adding a number when
+// labels clash with each other or existing ones
+func commandLabels(commands []HistoryCommand, saved OrderedMap) []string {
+	taken := map[string]bool{}
+	for _, key := range saved.Keys {
+		taken[key] = true
+	}
+
+	labels := make([]string, len(commands))
+	for i, command := range commands {
+		base := suggestCommandLabel(command.Command)
+		label := base
+		for n := 2; taken[label]; n++ {
+			label = fmt.Sprintf("%s %d", base, n)
+		}
+		taken[label] = true
+		labels[i] = label
+	}
+	return labels
+}
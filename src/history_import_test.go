@@ -0,0 +1,186 @@
+package src
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBashHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []HistoryEntry
+	}{
+		{
+			name: "plain",
+			data: "git status\n\nmake build\n",
+			want: []HistoryEntry{{Command: "git status"}, {Command: "make build"}},
+		},
+		{
+			name: "timestamps",
+			data: "old command\n#1700000000\ngit status\n#1700000060\nfor f in *; do\n  echo $f\ndone\n",
+			want: []HistoryEntry{
+				{Command: "old command"},
+				{Command: "git status", RunAt: time.Unix(1700000000, 0)},
+				{Command: "for f in *; do\n  echo $f\ndone", RunAt: time.Unix(1700000060, 0)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseBashHistory([]byte(tt.data)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseZshHistory(t *testing.T) {
+	// zsh writes → (e2 86 92) with its second and third bytes metafied
+	arrow := string([]byte{0xe2, 0x83, 0x86 ^ 32, 0x83, 0x92 ^ 32})
+
+	tests := []struct {
+		name string
+		data string
+		want []HistoryEntry
+	}{
+		{
+			name: "plain",
+			data: "git status\nmake build\n",
+			want: []HistoryEntry{{Command: "git status"}, {Command: "make build"}},
+		},
+		{
+			name: "extended",
+			data: ": 1700000000:0;git status\n: 1700000060:3;make build\n",
+			want: []HistoryEntry{
+				{Command: "git status", RunAt: time.Unix(1700000000, 0)},
+				{Command: "make build", RunAt: time.Unix(1700000060, 0)},
+			},
+		},
+		{
+			name: "continuation",
+			data: ": 1700000000:0;docker run \\\n  --rm \\\n  alpine\n: 1700000060:0;ls\n",
+			want: []HistoryEntry{
+				{Command: "docker run \n  --rm \n  alpine", RunAt: time.Unix(1700000000, 0)},
+				{Command: "ls", RunAt: time.Unix(1700000060, 0)},
+			},
+		},
+		{
+			name: "metafied",
+			data: ": 1700000000:0;echo " + arrow + "\n",
+			want: []HistoryEntry{{Command: "echo →", RunAt: time.Unix(1700000000, 0)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseZshHistory([]byte(tt.data)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFishHistory(t *testing.T) {
+	data := `- cmd: git status
+  when: 1700000000
+- cmd: echo a\nb \\ c
+  when: 1700000060
+  paths:
+    - /tmp
+- cmd: make
+`
+	want := []HistoryEntry{
+		{Command: "git status", RunAt: time.Unix(1700000000, 0)},
+		{Command: "echo a\nb \\ c", RunAt: time.Unix(1700000060, 0)},
+		{Command: "make"},
+	}
+	if got := parseFishHistory([]byte(data)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDetectHistoryShell(t *testing.T) {
+	tests := map[string]string{
+		"- cmd: ls\n  when: 1\n": FishShell,
+		": 1700000000:0;ls\n":    ZshShell,
+		"#1700000000\nls\n":      BashShell,
+		"\nls\n":                 BashShell,
+	}
+	for data, want := range tests {
+		if got := detectHistoryShell([]byte(data)); got != want {
+			t.Errorf("detectHistoryShell(%q) = %s, want %s", data, got, want)
+		}
+	}
+}
+
+func TestRankHistory(t *testing.T) {
+	at := func(seconds int64) time.Time { return time.Unix(seconds, 0) }
+	entries := []HistoryEntry{
+		{Command: "make build", RunAt: at(1)},
+		{Command: "make build ", RunAt: at(5)},
+		{Command: "make build", RunAt: at(3)},
+		{Command: "git push", RunAt: at(2)},
+		{Command: "git push", RunAt: at(4)},
+		{Command: "go test ./...", RunAt: at(1)},
+		{Command: "go test ./...", RunAt: at(2)},
+		{Command: "once"},
+		{Command: "cd /tmp"},
+		{Command: "cd /tmp"},
+		{Command: "saved"},
+		{Command: "saved"},
+	}
+	saved := OrderedMap{Keys: []string{"s"}, Values: map[string]string{"s": "saved"}}
+
+	got := rankHistory(entries, saved)
+	want := []HistoryCommand{
+		{Command: "make build", Runs: 3, LastRun: at(5)},
+		{Command: "git push", Runs: 2, LastRun: at(4)},
+		{Command: "go test ./...", Runs: 2, LastRun: at(2)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRankHistoryCap(t *testing.T) {
+	entries := []HistoryEntry{}
+	for i := range historyMaxCommands + 10 {
+		command := fmt.Sprintf("echo %d", i)
+		entries = append(entries, HistoryEntry{Command: command}, HistoryEntry{Command: command})
+	}
+	if got := rankHistory(entries, OrderedMap{}); len(got) != historyMaxCommands {
+		t.Errorf("got %d commands, want %d", len(got), historyMaxCommands)
+	}
+}
+
+func TestSuggestCommandLabel(t *testing.T) {
+	tests := map[string]string{
+		"git status":                      "git status",
+		"sudo systemctl restart nginx":    "systemctl restart nginx",
+		"FOO=1 make build -j8":            "make build",
+		"kubectl get pods -n web":         "kubectl get pods",
+		"docker compose up\n  --build":    "docker compose up",
+		"./deploy.sh staging":             "./deploy.sh staging",
+		"diverge --from main":             "Diverge",
+		"cat ~/notes.txt | grep todo now": "cat",
+	}
+	for command, want := range tests {
+		if got := suggestCommandLabel(command); got != want {
+			t.Errorf("suggestCommandLabel(%q) = %q, want %q", command, got, want)
+		}
+	}
+}
+
+func TestCommandLabels(t *testing.T) {
+	commands := []HistoryCommand{{Command: "make build"}, {Command: "make build -j8"}, {Command: "git status"}}
+	saved := OrderedMap{Keys: []string{"git status"}, Values: map[string]string{"git status": "git status -sb"}}
+
+	got := commandLabels(commands, saved)
+	want := []string{"make build", "make build 2", "git status 2"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
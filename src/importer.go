@@ -0,0 +1,414 @@
+package src
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Directory jumpers tg can import from
+const (
+	ZoxideImport   = "zoxide"
+	AutojumpImport = "autojump"
+	ZImport        = "z"
+	FasdImport     = "fasd"
+
+	// HistoryImport reads shell history into Commands instead
+	HistoryImport = "history"
+)
+
+// Frequency given to the highest ranked imported entry, the rest scale down from it
+const importMaxFrequency = 50
+
+// zoxide database format version this parser understands
+const zoxideDatabaseVersion = 3
+
+// ImportEntry is a directory read from another tool with its score there
+type ImportEntry struct {
+	Path  string
+	Score float64
+}
+
+// importOptions are the flags of tg import
+type importOptions struct {
+	source string
+	file   string
+	dryRun bool
+}
+
+const importUsage = `Usage: tg import <zoxide|autojump|z|fasd> [--file <path>] [--dry-run]
+       tg import history [bash|zsh|fish] [--file <path>] [--dry-run]
+
+  --file <path>  read this database, history or text export instead of the default location
+  --dry-run      list what would be imported without changing anything
+`
+
+// parseImportArgs reads the source and flags of tg import, in any order. The
+// source is empty when none was given.
+func parseImportArgs(args []string) (importOptions, error) {
+	var options importOptions
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--dry-run":
+			options.dryRun = true
+		case arg == "--file":
+			if i+1 >= len(args) {
+				return options, fmt.Errorf("--file needs a path")
+			}
+			i++
+			options.file = args[i]
+		case strings.HasPrefix(arg, "--file="):
+			options.file = strings.TrimPrefix(arg, "--file=")
+		case strings.HasPrefix(arg, "-"):
+			return options, fmt.Errorf("unknown flag %s", arg)
+		case options.source == "":
+			options.source = arg
+		default:
+			return options, fmt.Errorf("unexpected argument %s", arg)
+		}
+	}
+	return options, nil
+}
+
+// Import adds goTo entries from another directory jumper, seeding their frequency
+func (r *Runner) Import(args []string) {
+	if len(args) > 0 && args[0] == HistoryImport {
+		r.ImportHistory(args[1:])
+		return
+	}
+
+	importArgs, err := parseImportArgs(args)
+	if err == nil && importArgs.source == "" {
+		err = fmt.Errorf("missing source")
+	}
+	if err != nil {
+		r.utils.ExitWithError(fmt.Sprintf("%v\n\n%s", err, importUsage))
+	}
+
+	_, config, _ := r.load()
+	styles := DefaultStyles()
+
+	entries, err := ReadImportEntries(importArgs.source, importArgs.file)
+	if err != nil {
+		r.utils.HandleError(err, "Failed to import from "+importArgs.source)
+	}
+
+	entries, skipped := r.newImportEntries(entries, config)
+	if len(entries) == 0 {
+		fmt.Println(styles.Text(fmt.Sprintf("Nothing to import from %s (%d entries already in goTo or missing)", importArgs.source, skipped), styles.FooterColor))
+		return
+	}
+
+	labels := importLabels(entries, config.GoTo)
+	items := make([]ListItem, len(entries))
+	scores := map[string]float64{}
+	for i, entry := range entries {
+		items[i] = ListItem{T: labels[i], D: entry.Path}
+		scores[labels[i]] = entry.Score
+	}
+
+	if importArgs.dryRun {
+		fmt.Println(styles.Text(fmt.Sprintf("Would import %d goTo entries from %s:", len(items), importArgs.source), styles.TitleColor))
+		fmt.Println()
+		for _, item := range items {
+			fmt.Printf("  %-30s %s %s\n", item.T, item.D, styles.Text(fmt.Sprintf("(%.1f)", scores[item.T]), styles.MutedTitleColor))
+		}
+		if skipped > 0 {
+			fmt.Println()
+			fmt.Println(styles.Text(fmt.Sprintf("Skipped %d entries already in goTo or missing", skipped), styles.FooterColor))
+		}
+		return
+	}
+
+	chosen := r.viewBuilder.NewMultiSelectListView(fmt.Sprintf("Import from %s", importArgs.source), items, 20, true)
+	if len(chosen) == 0 {
+		return
+	}
+
+	// Imported entries go to a section of their own
+	addDivider(&config.GoTo, "📥 "+importArgs.source)
+
+	maxScore := entries[0].Score
+	for _, item := range chosen {
+		config.GoTo.Keys = append(config.GoTo.Keys, item.T)
+		config.GoTo.Values[item.T] = item.D
+	}
+
+	r.saveConfig(config)
+	r.updateGoToFrequency(func(goToFrequency *GoToFrequencyDTO) {
+		for _, item := range chosen {
+			goToFrequency.SeedGoTo(item.T, importFrequency(scores[item.T], maxScore))
+		}
+	})
+	fmt.Println(styles.Text(fmt.Sprintf("✓ Imported %d goTo entries from %s", len(chosen), importArgs.source), styles.AquamarineColor))
+}
+
+// newImportEntries drops directories that are missing or already in goTo and
+// sorts the rest by score
+func (r *Runner) newImportEntries(entries []ImportEntry, config *ConfigDTO) ([]ImportEntry, int) {
+	existing := map[string]bool{}
+	for _, entry := range goToEntries(config) {
+		existing[filepath.Clean(r.utils.ExpandPath(entry.Value))] = true
+	}
+
+	kept := []ImportEntry{}
+	for _, entry := range entries {
+		path := filepath.Clean(entry.Path)
+		if existing[path] {
+			continue
+		}
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			continue
+		}
+		existing[path] = true
+		kept = append(kept, ImportEntry{Path: path, Score: entry.Score})
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].Score > kept[j].Score
+	})
+	return kept, len(entries) - len(kept)
+}
+
+// importFrequency scales a score from another tool to a visit count
+func importFrequency(score, maxScore float64) int {
+	if maxScore <= 0 {
+		return 1
+	}
+	frequency := int(math.Ceil(score / maxScore * importMaxFrequency))
+	if frequency < 1 {
+		frequency = 1
+	}
+	return frequency
+}
+
+// importLabels names each entry after its directory, adding the parent
+// directory or a number when names clash with each other or existing labels
+func importLabels(entries []ImportEntry, goTo OrderedMap) []string {
+	taken := map[string]bool{}
+	for _, key := range goTo.Keys {
+		taken[key] = true
+	}
+
+	counts := map[string]int{}
+	for _, entry := range entries {
+		counts[filepath.Base(entry.Path)]++
+	}
+
+	labels := make([]string, len(entries))
+	for i, entry := range entries {
+		base := filepath.Base(entry.Path)
+		label := entryLabel(base)
+		if counts[base] > 1 || taken[label] {
+			label = entryLabel(filepath.Join(filepath.Base(filepath.Dir(entry.Path)), base))
+		}
+		for n := 2; taken[label]; n++ {
+			label = entryLabel(fmt.Sprintf("%s %d", base, n))
+		}
+		taken[label] = true
+		labels[i] = label
+	}
+	return labels
+}
+
+// addDivider appends a divider with the given text unless one already exists
+func addDivider(items *OrderedMap, text string) {
+	if items.Values == nil {
+		items.Values = map[string]string{}
+	}
+	for _, key := range items.Keys {
+		if isDividerKey(key) && items.Values[key] == text {
+			return
+		}
+	}
+
+	key := "div"
+	for n := 1; ; n++ {
+		if _, exists := items.Values[key]; !exists {
+			break
+		}
+		key = fmt.Sprintf("div%d", n)
+	}
+	items.Keys = append(items.Keys, key)
+	items.Values[key] = text
+}
+
+// ReadImportEntries reads the directories known to source, from file when set
+// or the tool's default location otherwise
+func ReadImportEntries(source, file string) ([]ImportEntry, error) {
+	if file == "" {
+		path, err := defaultImportPath(source)
+		if err != nil {
+			return nil, err
+		}
+		file = path
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("ReadImportEntries -> %v", err)
+	}
+
+	switch source {
+	case ZoxideImport:
+		// The database is binary, `zoxide query --list --score` prints text
+		if entries, err := parseZoxideDatabase(data); err == nil {
+			return entries, nil
+		}
+		return parseScoreFirst(data, " ")
+	case AutojumpImport:
+		return parseScoreFirst(data, "\t")
+	case ZImport, FasdImport:
+		return parsePipeSeparated(data)
+	default:
+		return nil, fmt.Errorf("unknown source %q, expected zoxide, autojump, z or fasd", source)
+	}
+}
+
+// defaultImportPath returns where each tool keeps its data, honoring the
+// environment variables they read
+func defaultImportPath(source string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("defaultImportPath -> %v", err)
+	}
+
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		dataDir = filepath.Join(home, ".local", "share")
+	}
+
+	switch source {
+	case ZoxideImport:
+		if dir := os.Getenv("_ZO_DATA_DIR"); dir != "" {
+			return filepath.Join(dir, "db.zo"), nil
+		}
+		if runtime.GOOS == "darwin" {
+			return filepath.Join(home, "Library", "Application Support", "zoxide", "db.zo"), nil
+		}
+		return filepath.Join(dataDir, "zoxide", "db.zo"), nil
+	case AutojumpImport:
+		if runtime.GOOS == "darwin" {
+			return filepath.Join(home, "Library", "autojump", "autojump.txt"), nil
+		}
+		return filepath.Join(dataDir, "autojump", "autojump.txt"), nil
+	case ZImport:
+		if path := os.Getenv("_Z_DATA"); path != "" {
+			return path, nil
+		}
+		return filepath.Join(home, ".z"), nil
+	case FasdImport:
+		if path := os.Getenv("_FASD_DATA"); path != "" {
+			return path, nil
+		}
+		return filepath.Join(home, ".fasd"), nil
+	default:
+		return "", fmt.Errorf("unknown source %q, expected zoxide, autojump, z or fasd", source)
+	}
+}
+
+// parseZoxideDatabase reads zoxide's db.zo: a bincode encoded version number
+// followed by a list of (path, rank, last accessed) entries
+func parseZoxideDatabase(data []byte) ([]ImportEntry, error) {
+	reader := bytes.NewReader(data)
+
+	var version uint32
+	if err := binary.Read(reader, binary.LittleEndian, &version); err != nil {
+		return nil, fmt.Errorf("parseZoxideDatabase -> %v", err)
+	}
+	if version != zoxideDatabaseVersion {
+		return nil, fmt.Errorf("parseZoxideDatabase -> unsupported version %d", version)
+	}
+
+	var count uint64
+	if err := binary.Read(reader, binary.LittleEndian, &count); err != nil {
+		return nil, fmt.Errorf("parseZoxideDatabase -> %v", err)
+	}
+
+	entries := []ImportEntry{}
+	for i := uint64(0); i < count; i++ {
+		var length uint64
+		if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+			return nil, fmt.Errorf("parseZoxideDatabase -> %v", err)
+		}
+		if length > uint64(reader.Len()) {
+			return nil, fmt.Errorf("parseZoxideDatabase -> truncated path")
+		}
+		path := make([]byte, length)
+		if _, err := io.ReadFull(reader, path); err != nil {
+			return nil, fmt.Errorf("parseZoxideDatabase -> %v", err)
+		}
+
+		var rank float64
+		var lastAccessed uint64
+		if err := binary.Read(reader, binary.LittleEndian, &rank); err != nil {
+			return nil, fmt.Errorf("parseZoxideDatabase -> %v", err)
+		}
+		if err := binary.Read(reader, binary.LittleEndian, &lastAccessed); err != nil {
+			return nil, fmt.Errorf("parseZoxideDatabase -> %v", err)
+		}
+
+		entries = append(entries, ImportEntry{Path: string(path), Score: rank})
+	}
+
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("parseZoxideDatabase -> unexpected trailing data")
+	}
+	return entries, nil
+}
+
+// parseScoreFirst reads "score<sep>path" lines, as written by autojump and
+// zoxide's text output
+func parseScoreFirst(data []byte, sep string) ([]ImportEntry, error) {
+	entries := []ImportEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		scoreText, path, ok := strings.Cut(line, sep)
+		if !ok {
+			return nil, fmt.Errorf("parseScoreFirst -> unexpected line %q", line)
+		}
+		score, err := strconv.ParseFloat(strings.TrimSpace(scoreText), 64)
+		if err != nil {
+			return nil, fmt.Errorf("parseScoreFirst -> unexpected line %q", line)
+		}
+		entries = append(entries, ImportEntry{Path: strings.TrimSpace(path), Score: score})
+	}
+	return entries, scanner.Err()
+}
+
+// parsePipeSeparated reads "path|rank|time" lines, as written by z and fasd
+func parsePipeSeparated(data []byte) ([]ImportEntry, error) {
+	entries := []ImportEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		// Paths may contain pipes, the rank and time are the last two fields
+		fields := strings.Split(line, "|")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("parsePipeSeparated -> unexpected line %q", line)
+		}
+		score, err := strconv.ParseFloat(fields[len(fields)-2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsePipeSeparated -> unexpected line %q", line)
+		}
+		path := strings.Join(fields[:len(fields)-2], "|")
+		entries = append(entries, ImportEntry{Path: path, Score: score})
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,234 @@
+package src
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// zoxideDatabase encodes entries the way zoxide writes db.zo
+func zoxideDatabase(version uint32, entries ...ImportEntry) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, version)
+	binary.Write(&b, binary.LittleEndian, uint64(len(entries)))
+	for _, entry := range entries {
+		binary.Write(&b, binary.LittleEndian, uint64(len(entry.Path)))
+		b.WriteString(entry.Path)
+		binary.Write(&b, binary.LittleEndian, entry.Score)
+		binary.Write(&b, binary.LittleEndian, uint64(1700000000))
+	}
+	return b.Bytes()
+}
+
+func TestParseZoxideDatabase(t *testing.T) {
+	entries := []ImportEntry{{Path: "/home/me/api", Score: 12.5}, {Path: "/srv/my dir", Score: 1}}
+	valid := zoxideDatabase(zoxideDatabaseVersion, entries...)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []ImportEntry
+		wantErr bool
+	}{
+		{name: "entries", data: valid, want: entries},
+		{name: "empty", data: zoxideDatabase(zoxideDatabaseVersion), want: []ImportEntry{}},
+		{name: "unknown version", data: zoxideDatabase(zoxideDatabaseVersion+1, entries...), wantErr: true},
+		{name: "truncated header", data: valid[:2], wantErr: true},
+		{name: "truncated path", data: valid[:20], wantErr: true},
+		{name: "truncated entry", data: valid[:len(valid)-4], wantErr: true},
+		{name: "trailing data", data: append(append([]byte{}, valid...), 0), wantErr: true},
+		{name: "text output", data: []byte("  12.5 /home/me/api\n"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseZoxideDatabase(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseScoreFirst(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		sep     string
+		want    []ImportEntry
+		wantErr bool
+	}{
+		{
+			name: "zoxide text",
+			data: "  12.5 /home/me/api\n   1.0 /srv/my dir\n\n",
+			sep:  " ",
+			want: []ImportEntry{{Path: "/home/me/api", Score: 12.5}, {Path: "/srv/my dir", Score: 1}},
+		},
+		{
+			name: "autojump",
+			data: "22.4\t/home/me/api\n10\t/home/me/with\ttab\n",
+			sep:  "\t",
+			want: []ImportEntry{{Path: "/home/me/api", Score: 22.4}, {Path: "/home/me/with\ttab", Score: 10}},
+		},
+		{name: "no separator", data: "/home/me/api\n", sep: "\t", wantErr: true},
+		{name: "score not a number", data: "high\t/home/me/api\n", sep: "\t", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseScoreFirst([]byte(tt.data), tt.sep)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePipeSeparated(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []ImportEntry
+		wantErr bool
+	}{
+		{
+			name: "z and fasd",
+			data: "/home/me/api|42|1700000000\n\n/home/me/a|b|3.5|1700000001\n",
+			want: []ImportEntry{{Path: "/home/me/api", Score: 42}, {Path: "/home/me/a|b", Score: 3.5}},
+		},
+		{name: "missing fields", data: "/home/me/api|42\n", wantErr: true},
+		{name: "rank not a number", data: "/home/me/api|x|1700000000\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePipeSeparated([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewImportEntries(t *testing.T) {
+	root := t.TempDir()
+	saved, api, web := filepath.Join(root, "saved"), filepath.Join(root, "api"), filepath.Join(root, "web")
+	for _, dir := range []string{saved, api, web} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := GetDefaultConfig()
+	config.GoTo.Keys = append(config.GoTo.Keys, "saved")
+	config.GoTo.Values["saved"] = saved
+
+	r := &Runner{utils: NewUtils()}
+	entries, skipped := r.newImportEntries([]ImportEntry{
+		{Path: api, Score: 1},
+		{Path: saved + "/", Score: 9},
+		{Path: web, Score: 5},
+		{Path: api + "/.", Score: 3},
+		{Path: filepath.Join(root, "gone"), Score: 7},
+	}, config)
+
+	want := []ImportEntry{{Path: web, Score: 5}, {Path: api, Score: 1}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("got %v, want %v", entries, want)
+	}
+	if skipped != 3 {
+		t.Errorf("skipped %d, want 3", skipped)
+	}
+}
+
+func TestImportLabels(t *testing.T) {
+	goTo := OrderedMap{Keys: []string{"home", "web"}, Values: map[string]string{"home": "~", "web": "~/web"}}
+	entries := []ImportEntry{
+		{Path: "/a/api"},
+		{Path: "/b/api"},
+		{Path: "/c/web"},
+		{Path: "/d/diva"},
+	}
+
+	got := importLabels(entries, goTo)
+	want := []string{"a/api", "b/api", "c/web", "Diva"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestImportFrequency(t *testing.T) {
+	tests := []struct {
+		score, max float64
+		want       int
+	}{
+		{score: 100, max: 100, want: importMaxFrequency},
+		{score: 50, max: 100, want: importMaxFrequency / 2},
+		{score: 0.001, max: 100, want: 1},
+		{score: 3, max: 0, want: 1},
+	}
+	for _, tt := range tests {
+		if got := importFrequency(tt.score, tt.max); got != tt.want {
+			t.Errorf("importFrequency(%v, %v) = %d, want %d", tt.score, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestSeedGoToKeepsCounts(t *testing.T) {
+	frequency := &GoToFrequencyDTO{}
+	frequency.SeedGoTo("api", 20)
+	frequency.SeedGoTo("api", 50)
+	if frequency.Frequencies["api"] != 20 {
+		t.Errorf("api = %d, want the first seed 20", frequency.Frequencies["api"])
+	}
+}
+
+func TestReadImportEntriesZoxide(t *testing.T) {
+	dir := t.TempDir()
+	database := filepath.Join(dir, "db.zo")
+	text := filepath.Join(dir, "list.txt")
+	if err := os.WriteFile(database, zoxideDatabase(zoxideDatabaseVersion, ImportEntry{Path: "/a", Score: 2}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(text, []byte("   2.0 /a\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ImportEntry{{Path: "/a", Score: 2}}
+	for _, file := range []string{database, text} {
+		got, err := ReadImportEntries(ZoxideImport, file)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", file, got, want)
+		}
+	}
+}
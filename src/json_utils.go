@@ -3,6 +3,7 @@ package src
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // ParseJSONContent parses JSON string into a struct
@@ -15,6 +16,16 @@ func ParseJSONContent[T any](content string) (*T, error) {
 	return &result, nil
 }
 
+// ParseJSONContentWithDefaults parses JSON string on top of defaults, so fields
+// missing from the content keep their default values
+func ParseJSONContentWithDefaults[T any](content string, defaults *T) (*T, error) {
+	err := json.Unmarshal([]byte(content), defaults)
+	if err != nil {
+		return nil, fmt.Errorf("ParseJSONContentWithDefaults -> %v", err)
+	}
+	return defaults, nil
+}
+
 // ToJSON converts a struct to JSON string
 func ToJSON[T any](data T) (string, error) {
 	bytes, err := json.MarshalIndent(data, "", "  ")
@@ -24,17 +35,29 @@ func ToJSON[T any](data T) (string, error) {
 	return string(bytes), nil
 }
 
+// isDividerKey returns true for keys that start a section instead of naming an entry
+func isDividerKey(key string) bool {
+	return strings.HasPrefix(key, "div")
+}
+
+// entryLabel keeps a label made from a directory name or a command from
+// reading as a divider key, capitalizing names such as diva
+func entryLabel(label string) string {
+	if isDividerKey(label) {
+		return "D" + label[1:]
+	}
+	return label
+}
+
 // ConfigItemsToListItems converts config items to list items maintaining JSON order
 func ConfigItemsToListItems(items OrderedMap) []ListItem {
 	listItems := []ListItem{}
 	for _, key := range items.Keys {
 		if value, ok := items.Values[key]; ok {
-			// Check if it's a divider (key starts with "div")
-			isDiv := len(key) >= 3 && key[:3] == "div"
 			listItems = append(listItems, ListItem{
 				T:     key,
 				D:     value,
-				IsDiv: isDiv,
+				IsDiv: isDividerKey(key),
 			})
 		}
 	}
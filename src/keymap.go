@@ -0,0 +1,327 @@
+package src
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+// Keymap preset names accepted by the "keymap" option
+const (
+	DefaultKeyMapName = "default"
+	VimKeyMapName     = "vim"
+	EmacsKeyMapName   = "emacs"
+)
+
+// KeyMap holds the key bindings of the multi-page view
+type KeyMap struct {
+	Up         key.Binding
+	Down       key.Binding
+	PageUp     key.Binding
+	PageDown   key.Binding
+	PrevPage   key.Binding
+	NextPage   key.Binding
+	Search     key.Binding
+	Select     key.Binding
+	View       key.Binding
+	Edit       key.Binding
+	EditConfig key.Binding
+	Paste      key.Binding
+	Secret     key.Binding
+	AddHere    key.Binding
+	Promote    key.Binding
+	// Alternate goTo actions
+	TmuxWindow  key.Binding
+	TmuxSession key.Binding
+	NewShell    key.Binding
+	// Rerun runs a past command again from the history page
+	Rerun key.Binding
+	// Menu opens the actions of the selected item
+	Menu      key.Binding
+	Quit      key.Binding
+	ForceQuit key.Binding
+}
+
+// DefaultKeyMap uses the arrow keys with h/j/k/l as alternatives
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:          newBinding("up", "k"),
+		Down:        newBinding("down", "j"),
+		PageUp:      newBinding("pgup", "b"),
+		PageDown:    newBinding("pgdown", "f"),
+		PrevPage:    newBinding("left", "h"),
+		NextPage:    newBinding("right", "l"),
+		Search:      newBinding("/"),
+		Select:      newBinding("enter"),
+		View:        newBinding("v"),
+		Edit:        newBinding("e"),
+		EditConfig:  newBinding("E"),
+		Paste:       newBinding("p"),
+		Secret:      newBinding("s"),
+		AddHere:     newBinding("a"),
+		Promote:     newBinding("+"),
+		TmuxWindow:  newBinding("alt+enter"),
+		TmuxSession: newBinding("alt+t"),
+		NewShell:    newBinding("alt+n"),
+		Rerun:       newBinding("r"),
+		Menu:        newBinding("tab", "."),
+		Quit:        newBinding("q", "esc"),
+		ForceQuit:   newBinding("ctrl+c"),
+	}
+}
+
+// VimKeyMap uses h/j/k/l with the arrow keys as alternatives
+func VimKeyMap() KeyMap {
+	return KeyMap{
+		Up:          newBinding("k", "ctrl+p", "up"),
+		Down:        newBinding("j", "ctrl+n", "down"),
+		PageUp:      newBinding("ctrl+b", "ctrl+u", "pgup"),
+		PageDown:    newBinding("ctrl+f", "ctrl+d", "pgdown"),
+		PrevPage:    newBinding("h", "left"),
+		NextPage:    newBinding("l", "right"),
+		Search:      newBinding("/"),
+		Select:      newBinding("enter"),
+		View:        newBinding("v"),
+		Edit:        newBinding("e"),
+		EditConfig:  newBinding("E"),
+		Paste:       newBinding("p"),
+		Secret:      newBinding("s"),
+		AddHere:     newBinding("a"),
+		Promote:     newBinding("+"),
+		TmuxWindow:  newBinding("alt+enter"),
+		TmuxSession: newBinding("alt+t"),
+		NewShell:    newBinding("alt+n"),
+		Rerun:       newBinding("r"),
+		Menu:        newBinding("tab", "."),
+		Quit:        newBinding("q", "esc"),
+		ForceQuit:   newBinding("ctrl+c"),
+	}
+}
+
+// EmacsKeyMap uses control key chords with the arrow keys as alternatives
+func EmacsKeyMap() KeyMap {
+	return KeyMap{
+		Up:          newBinding("ctrl+p", "up"),
+		Down:        newBinding("ctrl+n", "down"),
+		PageUp:      newBinding("alt+v", "pgup"),
+		PageDown:    newBinding("ctrl+v", "pgdown"),
+		PrevPage:    newBinding("ctrl+b", "left"),
+		NextPage:    newBinding("ctrl+f", "right"),
+		Search:      newBinding("ctrl+s"),
+		Select:      newBinding("enter", "ctrl+j"),
+		View:        newBinding("ctrl+o"),
+		Edit:        newBinding("ctrl+e"),
+		EditConfig:  newBinding("alt+e"),
+		Paste:       newBinding("ctrl+y"),
+		Secret:      newBinding("alt+s"),
+		AddHere:     newBinding("alt+a"),
+		Promote:     newBinding("alt+p"),
+		TmuxWindow:  newBinding("alt+enter"),
+		TmuxSession: newBinding("alt+t"),
+		NewShell:    newBinding("alt+n"),
+		Rerun:       newBinding("alt+r"),
+		Menu:        newBinding("tab", "alt+."),
+		Quit:        newBinding("ctrl+g", "esc"),
+		ForceQuit:   newBinding("ctrl+c"),
+	}
+}
+
+// ValidateKeyBindings reports key_bindings entries naming no action, so a typo
+// doesn't go unnoticed
+func ValidateKeyBindings(options *OptionsDTO) error {
+	unknown := []string{}
+	keyMap := DefaultKeyMap()
+	for action := range options.KeyBindings {
+		if keyMap.binding(action) == nil {
+			unknown = append(unknown, fmt.Sprintf("%q", action))
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("key_bindings: unknown action %s", strings.Join(unknown, ", "))
+}
+
+// NewKeyMap returns the preset named in options with any per-action overrides applied
+func NewKeyMap(options *OptionsDTO) KeyMap {
+	var keyMap KeyMap
+	switch options.KeyMap {
+	case VimKeyMapName:
+		keyMap = VimKeyMap()
+	case EmacsKeyMapName:
+		keyMap = EmacsKeyMap()
+	default:
+		keyMap = DefaultKeyMap()
+	}
+
+	for action, keys := range options.KeyBindings {
+		if binding := keyMap.binding(action); binding != nil && len(keys) > 0 {
+			*binding = newBinding(keys...)
+		}
+	}
+
+	return keyMap
+}
+
+// binding returns the binding for an action name as used in options.json
+func (k *KeyMap) binding(action string) *key.Binding {
+	switch action {
+	case "up":
+		return &k.Up
+	case "down":
+		return &k.Down
+	case "page_up":
+		return &k.PageUp
+	case "page_down":
+		return &k.PageDown
+	case "prev_page":
+		return &k.PrevPage
+	case "next_page":
+		return &k.NextPage
+	case "search":
+		return &k.Search
+	case "select":
+		return &k.Select
+	case "view":
+		return &k.View
+	case "edit":
+		return &k.Edit
+	case "edit_config":
+		return &k.EditConfig
+	case "paste":
+		return &k.Paste
+	case "secret":
+		return &k.Secret
+	case "add_here":
+		return &k.AddHere
+	case "promote":
+		return &k.Promote
+	case "tmux_window":
+		return &k.TmuxWindow
+	case "tmux_session":
+		return &k.TmuxSession
+	case "new_shell":
+		return &k.NewShell
+	case "rerun":
+		return &k.Rerun
+	case "menu":
+		return &k.Menu
+	case "quit":
+		return &k.Quit
+	case "force_quit":
+		return &k.ForceQuit
+	default:
+		return nil
+	}
+}
+
+// HelpText builds the footer help line from the active bindings
+func (k KeyMap) HelpText(page PageType, searchMode, multiPage bool) string {
+	var parts []string
+	if searchMode {
+		// Letters are part of the query, only the other keys work
+		parts = append(parts,
+			"type to search",
+			helpKeys(nonTextKeys(k.Up))+" "+helpKeys(nonTextKeys(k.Down))+" navigate",
+			helpKeys(nonTextKeys(k.Select))+" select",
+			helpKeys(nonTextKeys(k.Quit))+" cancel",
+		)
+		return strings.Join(parts, " • ")
+	}
+
+	parts = append(parts, helpKeys(k.Search)+" search")
+	if multiPage {
+		parts = append(parts, helpKeys(k.PrevPage)+" "+helpKeys(k.NextPage)+" switch")
+	}
+	parts = append(parts,
+		helpKeys(k.Up)+" "+helpKeys(k.Down)+" navigate",
+		helpKeys(k.Select)+" select",
+	)
+	if page != SettingsPage {
+		parts = append(parts, helpKeys(k.Menu)+" actions")
+	}
+	switch page {
+	case NotesPage:
+		parts = append(parts, helpKeys(k.View)+" view", helpKeys(k.Paste)+" paste", helpKeys(k.Secret)+" secret")
+	case ClipboardPage:
+		parts = append(parts, helpKeys(k.Paste)+" save as note")
+	case GoToPage:
+		parts = append(parts, helpKeys(k.AddHere)+" add here")
+	case FrequentPage:
+		parts = append(parts, helpKeys(k.AddHere)+" add here", helpKeys(k.Promote)+" save suggestion")
+	case HistoryPage:
+		parts = append(parts, helpKeys(k.Rerun)+" re-run")
+	}
+	if page == GoToPage || page == FrequentPage || page == ReposPage {
+		parts = append(parts, helpKeys(k.TmuxWindow)+" window", helpKeys(k.TmuxSession)+" session", helpKeys(k.NewShell)+" shell")
+	}
+	if isEditablePage(page) {
+		parts = append(parts, helpKeys(k.Edit)+" edit")
+	}
+	parts = append(parts, helpKeys(k.Quit)+" quit")
+	return strings.Join(parts, " • ")
+}
+
+// ScrollHelpText describes the keys that scroll the viewers
+func (k KeyMap) ScrollHelpText() string {
+	return strings.Join([]string{helpKeys(k.Up), helpKeys(k.Down), helpKeys(k.PageUp), helpKeys(k.PageDown)}, " ") + " scroll"
+}
+
+// ViewportKeyMap scrolls the viewers with the keys that move through lists
+func (k KeyMap) ViewportKeyMap() viewport.KeyMap {
+	return viewport.KeyMap{
+		Up:       k.Up,
+		Down:     k.Down,
+		PageUp:   k.PageUp,
+		PageDown: k.PageDown,
+	}
+}
+
+// NoteViewerHelpText builds the footer help line of the note viewer
+func (k KeyMap) NoteViewerHelpText() string {
+	return strings.Join([]string{
+		k.ScrollHelpText(),
+		helpKeys(k.Select) + " copy",
+		helpKeys(k.Quit) + " close",
+	}, " • ")
+}
+
+func newBinding(keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...))
+}
+
+// nonTextKeys leaves out the keys of a binding that type a character
+func nonTextKeys(binding key.Binding) key.Binding {
+	keys := []string{}
+	for _, k := range binding.Keys() {
+		if utf8.RuneCountInString(k) > 1 {
+			keys = append(keys, k)
+		}
+	}
+	return newBinding(keys...)
+}
+
+// helpKeys formats the keys of a binding, using arrows for the arrow keys
+func helpKeys(binding key.Binding) string {
+	names := map[string]string{
+		"up":     "↑",
+		"down":   "↓",
+		"left":   "←",
+		"right":  "→",
+		"pgdown": "pgdn",
+	}
+
+	keys := []string{}
+	for _, k := range binding.Keys() {
+		if name, ok := names[k]; ok {
+			k = name
+		}
+		keys = append(keys, k)
+	}
+	return strings.Join(keys, "/")
+}
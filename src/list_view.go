@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -13,6 +14,14 @@ type ListItem struct {
 	T     string
 	D     string
 	IsDiv bool
+	// Path is the file backing the item, for notes kept in the notes directory
+	Path string
+	// Suggestion is a visited directory that isn't saved to goTo yet
+	Suggestion bool
+	// Generated comes from a goTo pattern instead of config.json
+	Generated bool
+	// Dir is the directory a past run was started in
+	Dir string
 }
 
 func (i ListItem) Title() string       { return i.T }
@@ -72,12 +81,8 @@ func (m ListViewModel) View() string {
 	return m.list.View()
 }
 
-func ListView(title string, op []ListItem, height int, endValue *ListItem) {
-	items := []list.Item{}
-	for _, o := range op {
-		items = append(items, o)
-	}
-
+// newList builds the bubbles list shared by the list views
+func newList(title string, items []list.Item, height int) list.Model {
 	styles := DefaultStyles()
 
 	const defaultWidth = 20
@@ -96,6 +101,17 @@ func ListView(title string, op []ListItem, height int, endValue *ListItem) {
 	l.Styles.Title.Align(lipgloss.Left)
 	l.Styles.PaginationStyle = styles.PaginationStyle
 	l.Styles.HelpStyle = styles.HelpStyle
+	return l
+}
+
+func ListView(title string, op []ListItem, height int, endValue *ListItem) {
+	items := []list.Item{}
+	for _, o := range op {
+		items = append(items, o)
+	}
+
+	styles := DefaultStyles()
+	l := newList(title, items, height)
 
 	m := ListViewModel{list: l, endValue: endValue, selected: "", styles: *styles}
 
@@ -104,3 +120,119 @@ func ListView(title string, op []ListItem, height int, endValue *ListItem) {
 		os.Exit(1)
 	}
 }
+
+// checkItem is a list item with a checkbox, for the multi-select list
+type checkItem struct {
+	item    ListItem
+	checked bool
+}
+
+func (i checkItem) Title() string {
+	if i.checked {
+		return "◉ " + i.item.T
+	}
+	return "○ " + i.item.T
+}
+func (i checkItem) Description() string { return i.item.D }
+func (i checkItem) FilterValue() string { return i.item.T }
+
+type MultiSelectListViewModel struct {
+	list     list.Model
+	endValue *[]ListItem
+	quitting bool
+}
+
+func (m MultiSelectListViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m MultiSelectListViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		// Keys belong to the filter while typing one, and esc clears an
+		// applied filter before it cancels
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		if m.list.FilterState() == list.FilterApplied && msg.String() == "esc" {
+			break
+		}
+
+		switch msg.String() {
+		case " ", "x":
+			if i, ok := m.list.SelectedItem().(checkItem); ok {
+				i.checked = !i.checked
+				m.list.SetItem(m.list.GlobalIndex(), i)
+			}
+			return m, nil
+
+		case "a":
+			// Check everything, or nothing when everything is checked
+			items := m.list.Items()
+			allChecked := true
+			for _, item := range items {
+				allChecked = allChecked && item.(checkItem).checked
+			}
+			for index, item := range items {
+				i := item.(checkItem)
+				i.checked = !allChecked
+				m.list.SetItem(index, i)
+			}
+			return m, nil
+
+		case "enter":
+			m.quitting = true
+			*m.endValue = []ListItem{}
+			for _, item := range m.list.Items() {
+				if i := item.(checkItem); i.checked {
+					*m.endValue = append(*m.endValue, i.item)
+				}
+			}
+			return m, tea.Quit
+
+		case "ctrl+c", "esc", "q":
+			*m.endValue = nil
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m MultiSelectListViewModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	return m.list.View()
+}
+
+// MultiSelectListView lets the user check any number of items, starting all
+// checked or all unchecked. endValue is nil when the user cancels.
+func MultiSelectListView(title string, op []ListItem, height int, checked bool, endValue *[]ListItem) {
+	items := []list.Item{}
+	for _, o := range op {
+		items = append(items, checkItem{item: o, checked: checked})
+	}
+
+	l := newList(title, items, height)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "toggle")),
+			key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "all")),
+		}
+	}
+
+	m := MultiSelectListViewModel{list: l, endValue: endValue}
+
+	if _, err := tea.NewProgram(m).Run(); err != nil {
+		fmt.Println("MultiSelectListView -> ", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,142 @@
+package src
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Rows of the multi-page view, counted from the top of the screen
+const (
+	tabsRow       = 1 // After the leading blank line
+	listTopRow    = 3 // After the tabs and a blank line
+	searchBoxRows = 5 // Search box (3) and a blank line (2)
+	scrollUpRows  = 2 // "More items above" and a blank line
+)
+
+// handleMouse switches pages from the header tabs, selects items on click
+// and scrolls the viewport with the wheel
+func (m MultiPageViewModel) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scrollViewport(-1)
+
+	case tea.MouseButtonWheelDown:
+		m.scrollViewport(1)
+
+	case tea.MouseButtonLeft:
+		if msg.Y == tabsRow {
+			// Don't allow page navigation in search mode
+			if page := m.tabAt(msg.X); page >= 0 && !m.searchMode {
+				m.switchPage(page - m.pageIndex)
+			}
+			return m, nil
+		}
+
+		if msg.X >= m.listWidth() {
+			return m, nil
+		}
+
+		index := m.itemAt(msg.Y)
+		if index < 0 {
+			return m, nil
+		}
+
+		// A click on the selected item, which includes the second click of a
+		// double-click, works like enter. Modifiers pick a tmux window,
+		// session or shell only for directories.
+		if index == m.cursor {
+			modifiers := mouseModifiers(msg)
+			action := OpenAction
+			if m.isGoToPage() {
+				action = modifierAction(modifiers)
+			}
+			return m.selectCurrent(action, modifiers)
+		}
+		m.cursor = index
+	}
+
+	return m, nil
+}
+
+// tabAt returns the index of the header tab at column x, or -1
+func (m MultiPageViewModel) tabAt(x int) int {
+	left := 0
+	for i, tab := range m.renderTabs() {
+		right := left + lipgloss.Width(tab)
+		if x >= left && x < right {
+			return i
+		}
+		left = right
+	}
+	return -1
+}
+
+// itemAt returns the index of the selectable item rendered at row y, or -1
+func (m MultiPageViewModel) itemAt(y int) int {
+	items := m.getActiveList()
+
+	top := listTopRow
+	if m.searchMode {
+		top += searchBoxRows
+	}
+	if m.viewportStart > 0 {
+		top += scrollUpRows
+	}
+
+	for i := m.viewportStart; i < len(items) && i < m.viewportStart+m.maxVisible; i++ {
+		height := boxItemHeight
+		if m.compact || items[i].IsDiv {
+			height = compactItemHeight
+		}
+
+		if y >= top && y < top+height {
+			if items[i].IsDiv {
+				return -1
+			}
+			return i
+		}
+		top += height
+	}
+
+	return -1
+}
+
+// scrollViewport moves the viewport by delta items, dragging the cursor along
+// when it would leave the visible window
+func (m *MultiPageViewModel) scrollViewport(delta int) {
+	items := m.getActiveList()
+	maxStart := len(items) - m.maxVisible
+	if maxStart < 0 {
+		maxStart = 0
+	}
+
+	m.viewportStart += delta
+	if m.viewportStart > maxStart {
+		m.viewportStart = maxStart
+	}
+	if m.viewportStart < 0 {
+		m.viewportStart = 0
+	}
+
+	visibleEnd := m.viewportStart + m.maxVisible
+	if visibleEnd > len(items) {
+		visibleEnd = len(items)
+	}
+
+	if m.cursor < m.viewportStart {
+		m.cursor = m.viewportStart
+		for m.cursor < visibleEnd-1 && items[m.cursor].IsDiv {
+			m.cursor++
+		}
+	}
+	if m.cursor >= visibleEnd {
+		m.cursor = visibleEnd - 1
+		for m.cursor > m.viewportStart && items[m.cursor].IsDiv {
+			m.cursor--
+		}
+	}
+}
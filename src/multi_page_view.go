@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 type PageType int
@@ -17,39 +21,153 @@ const (
 	CommandsPage
 	NotesPage
 	SettingsPage
+	ClipboardPage
+	ReposPage
+	HistoryPage
+)
+
+// Layout defaults used until the terminal reports its size
+const (
+	defaultBoxWidth   = 70
+	defaultMaxVisible = 10
+
+	// Below these sizes the view switches to one line per item
+	compactMinWidth  = 50
+	compactMinHeight = 24
+
+	boxItemHeight     = 4 // Border, title, value, border
+	compactItemHeight = 1
 )
 
 type MultiPageViewModel struct {
-	config          *ConfigDTO
-	options         *OptionsDTO
-	goToFrequency   *GoToFrequencyDTO
-	currentPage     PageType
-	frequentList    []ListItem
-	goToList        []ListItem
-	commandList     []ListItem
-	notesList       []ListItem
-	settingsList    []ListItem
-	availPages      []PageType
-	pageIndex       int
-	cursor          int
-	viewportStart   int // First visible item index for scrolling
-	maxVisible      int // Maximum items to show at once
-	selected        *string
-	quitting        bool
-	styles          *Styles
+	config        *ConfigDTO
+	options       *OptionsDTO
+	goToFrequency *GoToFrequencyDTO
+	currentPage   PageType
+	frequentList  []ListItem
+	goToList      []ListItem
+	commandList   []ListItem
+	notesList     []ListItem
+	settingsList  []ListItem
+	clipboardList []ListItem
+	reposList     []ListItem
+	historyList   []ListItem
+	availPages    []PageType
+	pageIndex     int
+	cursor        int
+	viewportStart int // First visible item index for scrolling
+	maxVisible    int // Maximum items to show at once
+	width         int // Terminal width, 0 until the first WindowSizeMsg
+	height        int // Terminal height, 0 until the first WindowSizeMsg
+	compact       bool
+	selected      *Selection
+	quitting      bool
+	styles        *Styles
 	// Fuzzy find state
 	searchMode   bool
 	searchQuery  string
 	filteredList []ListItem
+	keys         KeyMap
+	// actions runs the selections that keep the view open
+	actions       *Actions
+	errorMessage  string
+	statusMessage string
+	statusID      int
+	// Inline editor for int and string settings
+	editingSetting *Setting
+	settingInput   textinput.Model
+	// Preview pane state, keyed by item value
+	dirPreviews map[string]*DirPreview
+	// Markdown note viewer, open when not nil
+	noteViewer    *noteViewer
+	markdownCache map[string]string
+	// Values copied by tg, newest first
+	clipboardHistory *ClipboardHistoryDTO
+	// Label prompt for a note created from the clipboard, open when not nil
+	notePrompt *textInputViewModel
+	noteDraft  string
+	// Label prompt for saving the working directory to goTo, open when not nil
+	bookmarkPrompt *bookmarkPrompt
+	// Actions of the selected item, open when not nil
+	actionMenu *actionMenu
+	// Passphrase for secret notes, kept for the session once entered
+	passphrase       string
+	passphrasePrompt *textInputViewModel
+	pendingSecret    *pendingSecret
+	// Last seen state of the files in the app directory, for live reload
+	fileStamps FileStamps
+	// Whether goTo targets exist, keyed by item value
+	pathStatus map[string]PathStatus
+	// When goTo targets last changed, a change reloads their preview
+	pathModTimes map[string]time.Time
+	// Last scan of the repo roots
+	repoCache *RepoCacheDTO
+	// Command run inside the TUI with its output, open when not nil
+	commandRun *commandRun
+	runID      int
 }
 
 func NewMultiPageViewModel(config *ConfigDTO, options *OptionsDTO, goToFrequency *GoToFrequencyDTO) MultiPageViewModel {
 	// Build frequent list if enabled and has data
+	frequentList := buildFrequentList(config, options, goToFrequency)
+
+	// Build settings list
+	settingsList := buildSettingsList(options)
+
+	// Build list of available pages (non-empty)
+	availPages := buildAvailPages(config, frequentList, []ListItem{}, []ListItem{}, []ListItem{})
+
+	currentPage := GoToPage
+	if len(availPages) > 0 {
+		currentPage = availPages[0]
+	}
+
+	m := MultiPageViewModel{
+		config:           config,
+		options:          options,
+		goToFrequency:    goToFrequency,
+		currentPage:      currentPage,
+		frequentList:     frequentList,
+		goToList:         buildGoToList(config),
+		commandList:      ConfigItemsToListItems(config.Commands),
+		notesList:        buildNotesList(config),
+		settingsList:     settingsList,
+		availPages:       availPages,
+		pageIndex:        0,
+		cursor:           0,
+		viewportStart:    0,
+		maxVisible:       defaultMaxVisible,
+		quitting:         false,
+		styles:           DefaultStyles(),
+		searchMode:       false,
+		searchQuery:      "",
+		filteredList:     []ListItem{},
+		dirPreviews:      map[string]*DirPreview{},
+		pathStatus:       map[string]PathStatus{},
+		pathModTimes:     map[string]time.Time{},
+		markdownCache:    map[string]string{},
+		clipboardHistory: GetDefaultClipboardHistory(),
+		clipboardList:    []ListItem{},
+		passphrase:       os.Getenv(PassphraseEnv),
+		keys:             NewKeyMap(options),
+	}
+
+	// Move cursor to first non-divider item
+	items := m.getCurrentList()
+	for m.cursor < len(items) && items[m.cursor].IsDiv {
+		m.cursor++
+	}
+
+	return m
+}
+
+// buildFrequentList lists the goTo items by visit count when enabled
+func buildFrequentList(config *ConfigDTO, options *OptionsDTO, goToFrequency *GoToFrequencyDTO) []ListItem {
 	var frequentList []ListItem
 	if options.FrequentGoTo && !goToFrequency.IsEmpty() {
 		topKeys := goToFrequency.GetTopGoToKeys()
 		for _, key := range topKeys {
-			if value, exists := config.GoTo.Values[key]; exists {
+			if value, exists := goToValue(config, key); exists {
 				frequentList = append(frequentList, ListItem{
 					T:     key,
 					D:     value,
@@ -58,11 +176,17 @@ func NewMultiPageViewModel(config *ConfigDTO, options *OptionsDTO, goToFrequency
 			}
 		}
 	}
+	if options.FrequentGoTo && options.TrackVisits {
+		if suggestions := buildSuggestionList(config, goToFrequency); len(suggestions) > 0 {
+			frequentList = append(frequentList, ListItem{T: "div", D: "💡 suggestions", IsDiv: true})
+			frequentList = append(frequentList, suggestions...)
+		}
+	}
+	return frequentList
+}
 
-	// Build settings list
-	settingsList := buildSettingsList(options)
-
-	// Build list of available pages (non-empty)
+// buildAvailPages lists the non-empty pages in display order
+func buildAvailPages(config *ConfigDTO, frequentList, reposList, clipboardList, historyList []ListItem) []PageType {
 	availPages := []PageType{}
 
 	// Add frequent page first if enabled and has items
@@ -73,66 +197,220 @@ func NewMultiPageViewModel(config *ConfigDTO, options *OptionsDTO, goToFrequency
 	if len(config.GoTo.Keys) > 0 {
 		availPages = append(availPages, GoToPage)
 	}
+	if len(reposList) > 0 {
+		availPages = append(availPages, ReposPage)
+	}
 	if len(config.Commands.Keys) > 0 {
 		availPages = append(availPages, CommandsPage)
 	}
-	if len(config.Notes.Keys) > 0 {
+	if hasNotes(config) {
 		availPages = append(availPages, NotesPage)
 	}
+	if len(clipboardList) > 0 {
+		availPages = append(availPages, ClipboardPage)
+	}
+	if len(historyList) > 0 {
+		availPages = append(availPages, HistoryPage)
+	}
 
 	// Always add settings page at the end
 	availPages = append(availPages, SettingsPage)
 
-	currentPage := GoToPage
-	if len(availPages) > 0 {
-		currentPage = availPages[0]
-	}
+	return availPages
+}
 
-	m := MultiPageViewModel{
-		config:        config,
-		options:       options,
-		goToFrequency: goToFrequency,
-		currentPage:   currentPage,
-		frequentList:  frequentList,
-		goToList:      ConfigItemsToListItems(config.GoTo),
-		commandList:   ConfigItemsToListItems(config.Commands),
-		notesList:     ConfigItemsToListItems(config.Notes),
-		settingsList:  settingsList,
-		availPages:    availPages,
-		pageIndex:     0,
-		cursor:        0,
-		viewportStart: 0,
-		maxVisible:    10, // Show max 10 items at a time
-		quitting:      false,
-		styles:        DefaultStyles(),
-		searchMode:    false,
-		searchQuery:   "",
-		filteredList:  []ListItem{},
-	}
+// rebuildPages refreshes the frequent list and available pages, staying on
+// the current page when it is still available
+func (m *MultiPageViewModel) rebuildPages() {
+	m.frequentList = buildFrequentList(m.config, m.options, m.goToFrequency)
+	m.availPages = buildAvailPages(m.config, m.frequentList, m.reposList, m.clipboardList, m.historyList)
 
-	// Move cursor to first non-divider item
-	items := m.getCurrentList()
-	for m.cursor < len(items) && items[m.cursor].IsDiv {
-		m.cursor++
+	for i, page := range m.availPages {
+		if page == m.currentPage {
+			m.pageIndex = i
+			return
+		}
 	}
 
-	return m
+	// The current page disappeared, fall back to the first one
+	m.pageIndex = 0
+	m.currentPage = m.availPages[0]
+	m.cursor = 0
+	m.viewportStart = 0
 }
 
 func (m MultiPageViewModel) Init() tea.Cmd {
-	return nil
+	if m.actions == nil {
+		return nil
+	}
+	return tea.Batch(m.actions.WatchFiles(), m.scanRepos())
 }
 
 func (m MultiPageViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	next := model.(MultiPageViewModel)
+
+	// Load the preview of whatever ended up selected and check new goTo targets
+	return next, tea.Batch(cmd, next.loadPreview(), next.checkPaths())
+}
+
+func (m MultiPageViewModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case actionResultMsg:
+		if msg.err != nil {
+			m.forgetWrongPassphrase(msg.err)
+			m.errorMessage = msg.err.Error()
+			return m, nil
+		}
+		if msg.frequencyCleared {
+			*m.goToFrequency = *GetDefaultGoToFrequency()
+			m.rebuildPages()
+		}
+		if msg.copied != "" {
+			m.recordCopy(msg.copied)
+		}
+		m.errorMessage = ""
+		m.statusMessage = msg.status
+		m.statusID++
+		id := m.statusID
+		return m, tea.Tick(statusDuration, func(time.Time) tea.Msg {
+			return clearStatusMsg{id: id}
+		})
+
+	case clearStatusMsg:
+		// Only clear the status if no newer one replaced it
+		if msg.id == m.statusID {
+			m.statusMessage = ""
+		}
+		return m, nil
+
+	case valueEditedMsg:
+		if msg.err != nil {
+			m.errorMessage = msg.err.Error()
+			return m, nil
+		}
+		return m.setConfigValue(msg)
+
+	case configEditedMsg:
+		if msg.err != nil {
+			m.errorMessage = msg.err.Error()
+			return m, nil
+		}
+		m.errorMessage = ""
+		m.setConfig(msg.config)
+		return m, nil
+
+	case selectionResolvedMsg:
+		if msg.missing != "" {
+			m.pathStatus[msg.missing] = PathMissing
+		}
+		if msg.err != nil {
+			m.errorMessage = msg.err.Error()
+			return m, nil
+		}
+		*m.selected = msg.selection
+		m.quitting = true
+		return m, tea.Quit
+
+	case secretToggledMsg:
+		if msg.err != nil {
+			m.forgetWrongPassphrase(msg.err)
+			m.errorMessage = msg.err.Error()
+			return m, nil
+		}
+		return m.setConfigValue(valueEditedMsg{page: NotesPage, key: msg.key, value: msg.value})
+
+	case clipboardPastedMsg:
+		if msg.err != nil {
+			m.errorMessage = msg.err.Error()
+			return m, nil
+		}
+		return m.openNotePrompt(msg.text)
+
+	case filesCheckedMsg:
+		return m.checkFiles(msg)
+
+	case reloadMsg:
+		return m.applyReload(msg)
+
+	case pathsCheckedMsg:
+		for value, status := range msg.statuses {
+			m.pathStatus[value] = status
+		}
+		for value, modTime := range msg.modTimes {
+			m.pathModTimes[value] = modTime
+		}
+		return m, nil
+
+	case reposScannedMsg:
+		if msg.err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to save the repository scan: %v", msg.err)
+		}
+		m.setRepoCache(msg.cache)
+		return m, nil
+
+	case runOutputMsg, runFinishedMsg:
+		return m.updateRun(msg)
+
+	case dirPreviewMsg:
+		preview := msg.preview
+		m.dirPreviews[msg.key] = &preview
+		return m, nil
+
+	case tea.MouseMsg:
+		if m.commandRun != nil {
+			return m.updateRunViewer(msg)
+		}
+		if m.noteViewer != nil {
+			return m.updateNoteViewer(msg)
+		}
+		if m.actionMenu != nil {
+			return m, nil
+		}
+		return m.handleMouse(msg)
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.updateLayout()
+		if m.noteViewer != nil {
+			m.resizeNoteViewer()
+		}
+		if m.commandRun != nil {
+			m.resizeRun()
+		}
+		return m, nil
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
-			*m.selected = ExitSignal
+		if m.commandRun != nil {
+			return m.updateRunViewer(msg)
+		}
+		if m.noteViewer != nil {
+			return m.updateNoteViewer(msg)
+		}
+		if m.passphrasePrompt != nil {
+			return m.updatePassphrasePrompt(msg)
+		}
+		if m.notePrompt != nil {
+			return m.updateNotePrompt(msg)
+		}
+		if m.bookmarkPrompt != nil {
+			return m.updateBookmarkPrompt(msg)
+		}
+		if m.actionMenu != nil {
+			return m.updateActionMenu(msg)
+		}
+		if m.editingSetting != nil {
+			return m.updateSettingInput(msg)
+		}
+
+		switch {
+		case m.keyMatches(msg, m.keys.ForceQuit):
+			*m.selected = Selection{Action: QuitAction}
 			m.quitting = true
 			return m, tea.Quit
 
-		case "esc", "q":
+		case m.keyMatches(msg, m.keys.Quit):
 			// If in search mode, exit search mode
 			if m.searchMode {
 				m.searchMode = false
@@ -145,14 +423,15 @@ func (m MultiPageViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				for m.cursor < len(items) && items[m.cursor].IsDiv {
 					m.cursor++
 				}
+				m.updateLayout()
 				return m, nil
 			}
 			// Otherwise quit
-			*m.selected = ExitSignal
+			*m.selected = Selection{Action: QuitAction}
 			m.quitting = true
 			return m, tea.Quit
 
-		case "/":
+		case m.keyMatches(msg, m.keys.Search):
 			// Enter search mode
 			if !m.searchMode {
 				m.searchMode = true
@@ -160,10 +439,11 @@ func (m MultiPageViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.filteredList = []ListItem{}
 				m.cursor = 0
 				m.viewportStart = 0
+				m.updateLayout()
 				return m, nil
 			}
 
-		case "backspace":
+		case msg.Type == tea.KeyBackspace:
 			// Handle backspace in search mode
 			if m.searchMode && len(m.searchQuery) > 0 {
 				m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
@@ -173,120 +453,84 @@ func (m MultiPageViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-		case "left":
+		case m.keyMatches(msg, m.keys.PrevPage):
 			// Don't allow page navigation in search mode
 			if m.searchMode {
 				return m, nil
 			}
-			// Navigate to previous page (circular)
-			if m.pageIndex > 0 {
-				m.pageIndex--
-			} else {
-				// Wrap to last page
-				m.pageIndex = len(m.availPages) - 1
-			}
-			m.currentPage = m.availPages[m.pageIndex]
-			m.cursor = 0
-			m.viewportStart = 0
-			// Skip dividers at start of page
-			items := m.getCurrentList()
-			for m.cursor < len(items) && items[m.cursor].IsDiv {
-				m.cursor++
-			}
+			m.switchPage(-1)
 
-		case "right":
+		case m.keyMatches(msg, m.keys.NextPage):
 			// Don't allow page navigation in search mode
 			if m.searchMode {
 				return m, nil
 			}
-			// Navigate to next page (circular)
-			if m.pageIndex < len(m.availPages)-1 {
-				m.pageIndex++
-			} else {
-				// Wrap to first page
-				m.pageIndex = 0
-			}
-			m.currentPage = m.availPages[m.pageIndex]
-			m.cursor = 0
-			m.viewportStart = 0
-			// Skip dividers at start of page
-			items := m.getCurrentList()
-			for m.cursor < len(items) && items[m.cursor].IsDiv {
-				m.cursor++
+			m.switchPage(1)
+
+		case m.keyMatches(msg, m.keys.Up):
+			m.moveUp()
+
+		case m.keyMatches(msg, m.keys.Down):
+			m.moveDown()
+
+		case m.keyMatches(msg, m.keys.Select):
+			return m.selectCurrent(OpenAction, keyModifiers(msg))
+
+		case m.keyMatches(msg, m.keys.TmuxWindow) && m.isGoToPage():
+			return m.selectCurrent(TmuxWindowAction, keyModifiers(msg))
+
+		case m.keyMatches(msg, m.keys.TmuxSession) && m.isGoToPage():
+			return m.selectCurrent(TmuxSessionAction, keyModifiers(msg))
+
+		case m.keyMatches(msg, m.keys.NewShell) && m.isGoToPage():
+			return m.selectCurrent(ShellAction, keyModifiers(msg))
+
+		case m.keyMatches(msg, m.keys.Rerun) && m.currentPage == HistoryPage:
+			return m.selectCurrent(OpenAction, keyModifiers(msg))
+
+		case m.keyMatches(msg, m.keys.View) && m.currentPage == NotesPage:
+			return m.openNoteViewer()
+
+		case m.keyMatches(msg, m.keys.Secret) && m.currentPage == NotesPage:
+			if item, ok := m.selectedItem(); ok {
+				return m.runSecretAction(toggleSecretAction, item)
 			}
 
-		case "up":
-			items := m.getCurrentList()
-			if m.cursor > 0 {
-				m.cursor--
-				// Skip dividers when navigating up
-				for m.cursor > 0 && items[m.cursor].IsDiv {
-					m.cursor--
-				}
-				// Scroll up if cursor moves above viewport with offset
-				if m.cursor < m.viewportStart+2 && m.viewportStart > 0 {
-					m.viewportStart--
-				}
-			} else {
-				// Wrap to last item
-				m.cursor = len(items) - 1
-				// Skip dividers from the end
-				for m.cursor > 0 && items[m.cursor].IsDiv {
-					m.cursor--
-				}
-				// Adjust viewport to show the last item
-				if len(items) > m.maxVisible {
-					m.viewportStart = len(items) - m.maxVisible
-				} else {
-					m.viewportStart = 0
-				}
+		case m.keyMatches(msg, m.keys.Paste) && m.currentPage == NotesPage:
+			if m.actions != nil {
+				return m, m.actions.PasteFromClipboard()
 			}
 
-		case "down":
-			items := m.getCurrentList()
-			if m.cursor < len(items)-1 {
-				m.cursor++
-				// Skip dividers when navigating down
-				for m.cursor < len(items)-1 && items[m.cursor].IsDiv {
-					m.cursor++
-				}
-				// Scroll down if cursor moves below viewport with offset
-				if m.cursor >= m.viewportStart+m.maxVisible-2 {
-					m.viewportStart++
-				}
-			} else {
-				// Wrap to first item
-				m.cursor = 0
-				// Skip dividers from the start
-				for m.cursor < len(items)-1 && items[m.cursor].IsDiv {
-					m.cursor++
-				}
-				// Reset viewport to top
-				m.viewportStart = 0
+		case m.keyMatches(msg, m.keys.Paste) && m.currentPage == ClipboardPage:
+			if item, ok := m.selectedItem(); ok {
+				return m.openNotePrompt(item.D)
 			}
 
-		case "enter":
-			items := m.getActiveList()
-			if len(items) > 0 && m.cursor < len(items) {
-				selectedItem := items[m.cursor]
-				result := fmt.Sprintf("%s|%s|%s", m.getPageName(), selectedItem.T, selectedItem.D)
-				*m.selected = result
-				m.quitting = true
-				return m, tea.Quit
+		case m.keyMatches(msg, m.keys.AddHere) && m.isGoToPage():
+			return m.openBookmarkPrompt()
+
+		case m.keyMatches(msg, m.keys.Promote) && m.currentPage == FrequentPage:
+			return m.promoteSuggestion()
+
+		case m.keyMatches(msg, m.keys.Menu):
+			return m.openActionMenu()
+
+		case m.keyMatches(msg, m.keys.Edit):
+			return m.editCurrent()
+
+		case m.keyMatches(msg, m.keys.EditConfig):
+			if m.actions != nil {
+				return m, m.actions.EditConfig()
 			}
 
 		default:
 			// Handle text input for search
-			if m.searchMode {
-				// Only accept single characters (letters, numbers, spaces, etc)
-				key := msg.String()
-				if len(key) == 1 {
-					m.searchQuery += key
-					m.updateFilteredList()
-					m.cursor = 0
-					m.viewportStart = 0
-					return m, nil
-				}
+			if m.searchMode && (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) {
+				m.searchQuery += string(msg.Runes)
+				m.updateFilteredList()
+				m.cursor = 0
+				m.viewportStart = 0
+				return m, nil
 			}
 		}
 	}
@@ -302,33 +546,468 @@ func (m MultiPageViewModel) View() string {
 	var b strings.Builder
 
 	// Header with tabs (only show non-empty pages)
+	b.WriteString("\n")
+	header := lipgloss.JoinHorizontal(lipgloss.Top, m.renderTabs()...)
+	if m.width > 0 {
+		header = ansi.Truncate(header, m.width, "…")
+	}
+	b.WriteString(header)
+	b.WriteString("\n\n")
+
+	// Prompts replace the list while open
+	if m.passphrasePrompt != nil {
+		b.WriteString(m.passphrasePrompt.View())
+		return b.String()
+	}
+	if m.notePrompt != nil {
+		b.WriteString(m.renderNotePrompt())
+		return b.String()
+	}
+	if m.bookmarkPrompt != nil {
+		b.WriteString(m.renderBookmarkPrompt())
+		return b.String()
+	}
+	if m.actionMenu != nil {
+		b.WriteString(m.renderActionMenu())
+		return b.String()
+	}
+
+	// The output of a command run inside tg replaces the list while shown
+	if m.commandRun != nil {
+		b.WriteString(m.renderRunViewer())
+		b.WriteString("\n\n")
+		b.WriteString(m.styles.FooterStyle.Render("  " + m.keys.RunViewerHelpText(m.commandRun.running) + "\n"))
+		return b.String()
+	}
+
+	// The note viewer replaces the list while open
+	if m.noteViewer != nil {
+		b.WriteString(m.renderNoteViewer())
+		b.WriteString("\n\n")
+		b.WriteString(m.styles.FooterStyle.Render("  " + m.keys.NoteViewerHelpText() + "\n"))
+		return b.String()
+	}
+
+	// List on the left, preview of the selected item on the right in full screen
+	list := m.renderList()
+	if m.previewEnabled() {
+		list = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(m.listWidth()).Render(list),
+			m.renderPreview(),
+		)
+	}
+	b.WriteString(list)
+
+	// Footer
+	b.WriteString("\n")
+	if m.errorMessage != "" {
+		b.WriteString(m.renderFooterLine(m.errorMessage, m.styles.ErrorColor))
+	} else if m.statusMessage != "" {
+		b.WriteString(m.renderFooterLine(m.statusMessage, m.styles.AquamarineColor))
+	}
+	helpText := "  " + m.keys.HelpText(m.currentPage, m.searchMode, len(m.availPages) > 1)
+	if m.width > 0 {
+		helpText = ansi.Truncate(helpText, m.width-2, "…")
+	}
+	b.WriteString(m.styles.FooterStyle.Render(helpText + "\n"))
+
+	return b.String()
+}
+
+// keyMatches checks a key against a binding. While searching, printable keys
+// are part of the query and never trigger bindings.
+func (m MultiPageViewModel) keyMatches(msg tea.KeyMsg, binding key.Binding) bool {
+	if m.searchMode && (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) {
+		return false
+	}
+	return key.Matches(msg, binding)
+}
+
+// switchPage moves to the previous (-1) or next (1) page, wrapping around
+func (m *MultiPageViewModel) switchPage(delta int) {
+	m.pageIndex = (m.pageIndex + delta + len(m.availPages)) % len(m.availPages)
+	m.currentPage = m.availPages[m.pageIndex]
+	m.cursor = 0
+	m.viewportStart = 0
+	// Skip dividers at start of page
+	items := m.getCurrentList()
+	for m.cursor < len(items) && items[m.cursor].IsDiv {
+		m.cursor++
+	}
+}
+
+// moveUp moves the cursor to the previous selectable item, wrapping to the last
+func (m *MultiPageViewModel) moveUp() {
+	items := m.getActiveList()
+	if len(items) == 0 {
+		return
+	}
+	if m.cursor > 0 {
+		m.cursor--
+		// Skip dividers when navigating up
+		for m.cursor > 0 && items[m.cursor].IsDiv {
+			m.cursor--
+		}
+		// Scroll up if cursor moves above viewport with offset
+		if m.cursor < m.viewportStart+2 && m.viewportStart > 0 {
+			m.viewportStart--
+		}
+	} else {
+		// Wrap to last item
+		m.cursor = len(items) - 1
+		// Skip dividers from the end
+		for m.cursor > 0 && items[m.cursor].IsDiv {
+			m.cursor--
+		}
+		// Adjust viewport to show the last item
+		if len(items) > m.maxVisible {
+			m.viewportStart = len(items) - m.maxVisible
+		} else {
+			m.viewportStart = 0
+		}
+	}
+	m.clampViewport()
+}
+
+// moveDown moves the cursor to the next selectable item, wrapping to the first
+func (m *MultiPageViewModel) moveDown() {
+	items := m.getActiveList()
+	if len(items) == 0 {
+		return
+	}
+	if m.cursor < len(items)-1 {
+		m.cursor++
+		// Skip dividers when navigating down
+		for m.cursor < len(items)-1 && items[m.cursor].IsDiv {
+			m.cursor++
+		}
+		// Scroll down if cursor moves below viewport with offset
+		if m.cursor >= m.viewportStart+m.maxVisible-2 {
+			m.viewportStart++
+		}
+	} else {
+		// Wrap to first item
+		m.cursor = 0
+		// Skip dividers from the start
+		for m.cursor < len(items)-1 && items[m.cursor].IsDiv {
+			m.cursor++
+		}
+		// Reset viewport to top
+		m.viewportStart = 0
+	}
+	m.clampViewport()
+}
+
+// selectCurrent runs the action of the item under the cursor, quitting with
+// the selection when the action leaves the shell. Only goTo items have
+// alternate actions, OpenAction picks the one configured for the item.
+func (m MultiPageViewModel) selectCurrent(action SelectionAction, modifiers Modifiers) (tea.Model, tea.Cmd) {
+	items := m.getActiveList()
+	if len(items) > 0 && m.cursor < len(items) {
+		selectedItem := items[m.cursor]
+
+		// Settings are edited in place
+		if m.currentPage == SettingsPage {
+			if setting, ok := findSetting(selectedItem.T); ok && setting.Type != ActionSetting {
+				return m.editSetting(setting)
+			}
+		}
+
+		// Secrets are decrypted only to be copied
+		if m.currentPage == NotesPage && IsSecret(selectedItem.D) {
+			return m.runSecretAction(copySecretAction, selectedItem)
+		}
+
+		// Past runs and, in subprocess mode, commands run inside the view
+		if m.currentPage == HistoryPage {
+			label, command, dir := historyRun(selectedItem)
+			return m.startRun(label, command, dir)
+		}
+		if m.currentPage == CommandsPage && m.options.CommandMode == SubprocessCommandMode && action == OpenAction {
+			return m.startRun(selectedItem.T, selectedItem.D, "")
+		}
+
+		// Actions that don't leave the shell keep the view open
+		if m.actions != nil {
+			if cmd, ok := m.actions.ForItem(m.currentPage, selectedItem); ok {
+				return m, cmd
+			}
+		}
+
+		if !m.isGoToPage() {
+			action = OpenAction
+		} else if action == OpenAction {
+			action = goToAction(m.options, selectedItem.T)
+		}
+		return m.openSelection(selectedItem, action, modifiers)
+	}
+	return m, nil
+}
+
+// openSelection ends the view with item selected for action, once its value
+// is resolved
+func (m MultiPageViewModel) openSelection(item ListItem, action SelectionAction, modifiers Modifiers) (tea.Model, tea.Cmd) {
+	selection := Selection{
+		Page:      m.currentPage,
+		Item:      item,
+		Value:     item.D,
+		Action:    action,
+		Modifiers: modifiers,
+	}
+	if m.actions != nil {
+		return m, m.actions.Resolve(selection)
+	}
+	*m.selected = selection
+	m.quitting = true
+	return m, tea.Quit
+}
+
+// updateLayout recomputes the layout mode and how many items fit on screen
+func (m *MultiPageViewModel) updateLayout() {
+	if m.width == 0 || m.height == 0 {
+		return
+	}
+
+	m.compact = m.width < compactMinWidth || m.height < compactMinHeight
+
+	itemHeight := boxItemHeight
+	if m.compact {
+		itemHeight = compactItemHeight
+	}
+
+	// Header (3) + scroll indicators (4) + footer (3) + search box (5)
+	chrome := 10
+	if m.searchMode {
+		chrome += 5
+	}
+	available := m.height - chrome
+	m.maxVisible = available / itemHeight
+	if m.maxVisible < 1 {
+		m.maxVisible = 1
+	}
+
+	m.clampViewport()
+}
+
+// clampViewport keeps the cursor inside the visible window
+func (m *MultiPageViewModel) clampViewport() {
+	if m.cursor < m.viewportStart {
+		m.viewportStart = m.cursor
+	}
+	if m.cursor >= m.viewportStart+m.maxVisible {
+		m.viewportStart = m.cursor - m.maxVisible + 1
+	}
+
+	maxStart := len(m.getActiveList()) - m.maxVisible
+	if m.viewportStart > maxStart {
+		m.viewportStart = maxStart
+	}
+	if m.viewportStart < 0 {
+		m.viewportStart = 0
+	}
+}
+
+// boxWidth returns the item box width for the current terminal size
+func (m MultiPageViewModel) boxWidth() int {
+	if m.width == 0 {
+		return defaultBoxWidth
+	}
+
+	// Leave room for the border (2) and the selected item indent (2)
+	width := m.listWidth() - 5
+	if width < 10 {
+		width = 10
+	}
+	return width
+}
+
+// renderDivider renders a section divider across the box width
+func (m MultiPageViewModel) renderDivider(item ListItem) string {
+	dividerText := fmt.Sprintf("─── %s ───", item.D)
+	dividerStyle := lipgloss.NewStyle().
+		Foreground(m.styles.DividerColor).
+		Italic(true).
+		Width(m.boxWidth()).
+		Align(lipgloss.Center)
+	return dividerStyle.Render(ansi.Truncate(dividerText, m.boxWidth(), "…"))
+}
+
+// itemColors returns the title style and value color for an item
+func (m MultiPageViewModel) itemColors(selected bool) (lipgloss.Style, lipgloss.Color) {
+	isSettingsPage := m.currentPage == SettingsPage
+	titleStyle := lipgloss.NewStyle().Bold(true)
+
+	if selected {
+		if isSettingsPage {
+			return titleStyle.Foreground(m.styles.SettingsSelectedTitleColor), m.styles.SettingsValueColor
+		}
+		return titleStyle.Foreground(m.styles.SelectedTitleColor), m.styles.FooterColor
+	}
+
+	if isSettingsPage {
+		return titleStyle.Foreground(m.styles.SettingsTitleColor), m.styles.SettingsValueColor
+	}
+	return titleStyle.Foreground(m.styles.MutedTitleColor), m.styles.MutedTitleColor
+}
+
+// valueColor picks the value color, using enabled/disabled colors for settings toggles
+func (m MultiPageViewModel) valueColor(item ListItem, defaultColor lipgloss.Color) lipgloss.Color {
+	if m.isMissing(item) {
+		return m.styles.ErrorColor
+	}
+	if m.currentPage != SettingsPage {
+		return defaultColor
+	}
+	if setting, ok := findSetting(item.T); ok && setting.inactiveReason(m.options) != "" {
+		return m.styles.MutedTitleColor
+	}
+
+	value := strings.ToLower(item.D)
+	if strings.Contains(value, "enabled") {
+		return m.styles.SettingsEnabledColor
+	}
+	if strings.Contains(value, "disabled") {
+		return m.styles.SettingsDisabledColor
+	}
+	return defaultColor
+}
+
+// itemTexts returns the title and value to display, highlighted when searching
+func (m MultiPageViewModel) itemTexts(item ListItem) (string, string) {
+	// The setting being edited shows its input instead of the value
+	if m.editingSetting != nil && m.currentPage == SettingsPage && item.T == m.editingSetting.Key {
+		return item.T, m.settingInput.View()
+	}
+
+	// Values are shown on a single line and truncated, never wrapped.
+	// Multi-line values show their first line and how many lines follow.
+	value := item.D
+	if lines := strings.Split(value, "\n"); len(lines) > 1 {
+		value = fmt.Sprintf("%s ⏎ +%d lines", lines[0], len(lines)-1)
+	}
+	if m.isMissing(item) {
+		value += " ✗ missing"
+	}
+
+	if IsSecret(item.D) {
+		if m.searchMode && m.searchQuery != "" {
+			return m.highlightMatches(item.T, m.searchQuery), SecretMask
+		}
+		return item.T, SecretMask
+	}
+
+	if m.searchMode && m.searchQuery != "" {
+		return m.highlightMatches(item.T, m.searchQuery), m.highlightMatches(value, m.searchQuery)
+	}
+	return item.T, value
+}
+
+// renderItemBox renders an item as a bordered box with title and value lines
+func (m MultiPageViewModel) renderItemBox(item ListItem, selected bool) string {
+	isSettingsPage := m.currentPage == SettingsPage
+	width := m.boxWidth()
+
+	itemBox := lipgloss.NewStyle().
+		Border(m.styles.Border).
+		Padding(0, 1).
+		Width(width)
+
+	if selected {
+		// Selected item - with bright border and indented
+		borderColor := m.styles.SelectedTitleColor
+		if isSettingsPage {
+			borderColor = m.styles.SettingsSelectedTitleColor
+		}
+		itemBox = itemBox.BorderForeground(borderColor).MarginLeft(2)
+	} else {
+		// Unselected item - subtle border
+		borderColor := m.styles.MutedBorderColor
+		if isSettingsPage {
+			borderColor = m.styles.SettingsBorderColor
+		}
+		itemBox = itemBox.BorderForeground(borderColor)
+	}
+
+	titleStyle, defaultValueColor := m.itemColors(selected)
+	valueStyle := lipgloss.NewStyle().
+		Foreground(m.valueColor(item, defaultValueColor)).
+		Italic(true)
+
+	// Content width is the box width minus horizontal padding
+	contentWidth := width - 2
+	titleText, valueText := m.itemTexts(item)
+
+	content := fmt.Sprintf("%s\n%s",
+		titleStyle.Render(ansi.Truncate(titleText, contentWidth, "…")),
+		valueStyle.Render(ansi.Truncate(valueText, contentWidth, "…")),
+	)
+
+	return itemBox.Render(content)
+}
+
+// renderCompactItem renders an item on a single line for small terminals
+func (m MultiPageViewModel) renderCompactItem(item ListItem, selected bool) string {
+	titleStyle, defaultValueColor := m.itemColors(selected)
+	valueStyle := lipgloss.NewStyle().
+		Foreground(m.valueColor(item, defaultValueColor)).
+		Italic(true)
+
+	marker := "  "
+	if selected {
+		marker = titleStyle.Render("› ")
+	}
+
+	titleText, valueText := m.itemTexts(item)
+	line := marker + titleStyle.Render(titleText) + " " + valueStyle.Render(valueText)
+
+	width := m.listWidth()
+	if width == 0 {
+		width = defaultBoxWidth
+	}
+	return ansi.Truncate(line, width-1, "…")
+}
+
+// renderFooterLine renders a status or error line above the help text
+func (m MultiPageViewModel) renderFooterLine(text string, color lipgloss.Color) string {
+	text = "  " + strings.ReplaceAll(text, "\n", " ")
+	if m.width > 0 {
+		text = ansi.Truncate(text, m.width-2, "…")
+	}
+	return m.styles.FooterStyle.Render(m.styles.Text(text, color)) + "\n"
+}
+
+// renderTabs renders one header tab per available page
+func (m MultiPageViewModel) renderTabs() []string {
 	var tabViews []string
 	for _, page := range m.availPages {
 		pageName := m.getPageNameByType(page)
 		if page == m.currentPage {
-			tabViews = append(tabViews, m.styles.Text(fmt.Sprintf("[ %s ]", pageName), m.styles.SelectedTitleColor))
+			tabViews = append(tabViews, m.styles.Text(fmt.Sprintf(m.styles.SelectedTabFormat, pageName), m.styles.SelectedTitleColor))
 		} else {
-			tabViews = append(tabViews, m.styles.Text(fmt.Sprintf("  %s  ", pageName), m.styles.MutedTitleColor))
+			tabViews = append(tabViews, m.styles.Text(fmt.Sprintf(m.styles.TabFormat, pageName), m.styles.MutedTitleColor))
 		}
 	}
-	b.WriteString("\n")
-	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, tabViews...))
-	b.WriteString("\n\n")
+	return tabViews
+}
+
+// renderList renders the search box and the visible items of the current page
+func (m MultiPageViewModel) renderList() string {
+	var b strings.Builder
 
 	// Show search box if in search mode
 	if m.searchMode {
 		searchBox := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
+			Border(m.styles.Border).
 			BorderForeground(m.styles.SearchBoxColor).
 			Padding(0, 1).
-			Width(70).
+			Width(m.boxWidth()).
 			Foreground(m.styles.SearchTextColor)
 
 		searchText := fmt.Sprintf("🔍 Search: %s", m.searchQuery)
 		if m.searchQuery == "" {
 			searchText = "🔍 Search: (type to search...)"
 		}
-		b.WriteString(searchBox.Render(searchText))
+		b.WriteString(searchBox.Render(ansi.Truncate(searchText, m.boxWidth()-2, "…")))
 		b.WriteString("\n\n")
 	}
 
@@ -359,115 +1038,16 @@ func (m MultiPageViewModel) View() string {
 
 			// Check if this is a divider
 			if item.IsDiv {
-				// Render divider with subtle styling
-				dividerText := fmt.Sprintf("─── %s ───", item.D)
-				dividerStyle := lipgloss.NewStyle().
-					Foreground(m.styles.DividerColor).
-					Italic(true).
-					Width(70).
-					Align(lipgloss.Center)
-				b.WriteString(dividerStyle.Render(dividerText))
+				b.WriteString(m.renderDivider(item))
 				b.WriteString("\n")
 				continue
 			}
 
-			// Style for item box
-			var itemBox lipgloss.Style
-			var isSettingsPage = m.currentPage == SettingsPage
-
-			if m.cursor == i {
-				// Selected item - with bright border and indented
-				borderColor := m.styles.SelectedTitleColor
-				if isSettingsPage {
-					borderColor = m.styles.SettingsSelectedTitleColor
-				}
-				itemBox = lipgloss.NewStyle().
-					Border(lipgloss.RoundedBorder()).
-					BorderForeground(borderColor).
-					Padding(0, 1).
-					Width(70).
-					MarginLeft(2) // Indent selected item
-			} else {
-				// Unselected item - subtle border
-				borderColor := m.styles.MutedBorderColor
-				if isSettingsPage {
-					borderColor = m.styles.SettingsBorderColor
-				}
-				itemBox = lipgloss.NewStyle().
-					Border(lipgloss.RoundedBorder()).
-					BorderForeground(borderColor).
-					Padding(0, 1).
-					Width(70)
-			}
-
-			// Title (label) - bold and prominent
-			titleStyle := lipgloss.NewStyle().Bold(true)
-			var valueColor lipgloss.Color
-			if m.cursor == i {
-				if isSettingsPage {
-					titleStyle = titleStyle.Foreground(m.styles.SettingsSelectedTitleColor)
-					valueColor = m.styles.SettingsValueColor
-				} else {
-					titleStyle = titleStyle.Foreground(m.styles.SelectedTitleColor)
-					valueColor = m.styles.FooterColor
-				}
-			} else {
-				if isSettingsPage {
-					titleStyle = titleStyle.Foreground(m.styles.SettingsTitleColor)
-					valueColor = m.styles.SettingsValueColor
-				} else {
-					titleStyle = titleStyle.Foreground(m.styles.MutedTitleColor)
-					valueColor = m.styles.MutedTitleColor
-				}
-			}
-
-			// Value - smaller and wrapped
-			valueStyle := lipgloss.NewStyle().
-				Foreground(valueColor).
-				Width(66). // Slightly less than box width for padding
-				Italic(true)
-
-			// Build content with highlighting if in search mode
-			var titleText, valueText string
-			if m.searchMode && m.searchQuery != "" {
-				titleText = m.highlightMatches(item.T, m.searchQuery)
-				valueText = m.highlightMatches(item.D, m.searchQuery)
-			} else {
-				titleText = item.T
-				valueText = item.D
-			}
-
-			// Apply special colors for settings toggles (enabled/disabled)
-			var renderedValue string
-			if isSettingsPage {
-				if strings.Contains(strings.ToLower(item.D), "enabled") {
-					// Use green color for enabled
-					enabledStyle := lipgloss.NewStyle().
-						Foreground(m.styles.SettingsEnabledColor).
-						Width(66).
-						Italic(true)
-					renderedValue = enabledStyle.Render(valueText)
-				} else if strings.Contains(strings.ToLower(item.D), "disabled") {
-					// Use red color for disabled
-					disabledStyle := lipgloss.NewStyle().
-						Foreground(m.styles.SettingsDisabledColor).
-						Width(66).
-						Italic(true)
-					renderedValue = disabledStyle.Render(valueText)
-				} else {
-					// Default value style for non-toggle settings
-					renderedValue = valueStyle.Render(valueText)
-				}
+			if m.compact {
+				b.WriteString(m.renderCompactItem(item, m.cursor == i))
 			} else {
-				renderedValue = valueStyle.Render(valueText)
+				b.WriteString(m.renderItemBox(item, m.cursor == i))
 			}
-
-			content := fmt.Sprintf("%s\n%s",
-				titleStyle.Render(titleText),
-				renderedValue,
-			)
-
-			b.WriteString(itemBox.Render(content))
 			b.WriteString("\n")
 		}
 
@@ -478,19 +1058,6 @@ func (m MultiPageViewModel) View() string {
 		}
 	}
 
-	// Footer
-	b.WriteString("\n")
-	var helpText string
-	if m.searchMode {
-		helpText = "  type to search • ↑↓ navigate • enter select • esc cancel"
-	} else {
-		helpText = "  / search • ↑↓ navigate • enter select • q/esc quit"
-		if len(m.availPages) > 1 {
-			helpText = "  / search • ← → switch • ↑↓ navigate • enter select • q/esc quit"
-		}
-	}
-	b.WriteString(m.styles.FooterStyle.Render(helpText + "\n"))
-
 	return b.String()
 }
 
@@ -506,28 +1073,17 @@ func (m MultiPageViewModel) getCurrentList() []ListItem {
 		return m.notesList
 	case SettingsPage:
 		return m.settingsList
+	case ClipboardPage:
+		return m.clipboardList
+	case ReposPage:
+		return m.reposList
+	case HistoryPage:
+		return m.historyList
 	default:
 		return []ListItem{}
 	}
 }
 
-func (m MultiPageViewModel) getPageName() string {
-	switch m.currentPage {
-	case FrequentPage:
-		return "frequent"
-	case GoToPage:
-		return "goTo"
-	case CommandsPage:
-		return "commands"
-	case NotesPage:
-		return "notes"
-	case SettingsPage:
-		return "settings"
-	default:
-		return ""
-	}
-}
-
 func (m MultiPageViewModel) getPageNameByType(page PageType) string {
 	switch page {
 	case FrequentPage:
@@ -540,16 +1096,53 @@ func (m MultiPageViewModel) getPageNameByType(page PageType) string {
 		return "notes ✏️"
 	case SettingsPage:
 		return "settings ⚙️"
+	case ClipboardPage:
+		return "clipboard 📋"
+	case ReposPage:
+		return "repos 🌿"
+	case HistoryPage:
+		return "history 🕘"
 	default:
 		return ""
 	}
 }
 
-func MultiPageView(config *ConfigDTO, options *OptionsDTO, goToFrequency *GoToFrequencyDTO, selected *string) {
+func MultiPageView(config *ConfigDTO, options *OptionsDTO, goToFrequency *GoToFrequencyDTO, actions *Actions, selected *Selection) {
 	m := NewMultiPageViewModel(config, options, goToFrequency)
 	m.selected = selected
+	m.actions = actions
+	if actions != nil {
+		m.fileStamps = actions.SnapshotFiles()
 
-	if _, err := tea.NewProgram(m).Run(); err != nil {
+		history, err := actions.LoadClipboardHistory()
+		if err != nil {
+			m.errorMessage = err.Error()
+		} else {
+			m.setClipboardHistory(history)
+		}
+
+		if records, err := actions.LoadRunHistory(); err != nil {
+			m.errorMessage = err.Error()
+		} else {
+			m.setRunHistory(records)
+		}
+
+		// Show the last scan until the one started by Init ends
+		if cache, err := actions.LoadRepoCache(); err == nil {
+			m.setRepoCache(cache)
+		}
+	}
+
+	var programOptions []tea.ProgramOption
+	if options.FullScreen {
+		programOptions = append(programOptions, tea.WithAltScreen())
+		// Mouse positions only map onto the view when it owns the whole screen
+		if options.Mouse {
+			programOptions = append(programOptions, tea.WithMouseCellMotion())
+		}
+	}
+
+	if _, err := tea.NewProgram(m, programOptions...).Run(); err != nil {
 		fmt.Println("MultiPageView -> ", err)
 		os.Exit(1)
 	}
@@ -584,6 +1177,10 @@ func (m *MultiPageViewModel) updateFilteredList() {
 		// Check if query matches in title or description
 		titleLower := strings.ToLower(item.T)
 		descLower := strings.ToLower(item.D)
+		// Secret values only match by label
+		if IsSecret(item.D) {
+			descLower = ""
+		}
 
 		if fuzzyMatch(titleLower, query) || fuzzyMatch(descLower, query) {
 			m.filteredList = append(m.filteredList, item)
@@ -620,6 +1217,10 @@ func (m MultiPageViewModel) highlightMatches(text, query string) string {
 	highlightStyle := lipgloss.NewStyle().
 		Background(m.styles.HighlightBgColor).
 		Foreground(m.styles.HighlightFgColor)
+	// Themes without colors underline the matches instead
+	if m.styles.HighlightBgColor == "" {
+		highlightStyle = highlightStyle.Underline(true)
+	}
 
 	textLower := strings.ToLower(text)
 	queryLower := strings.ToLower(query)
@@ -642,31 +1243,3 @@ func (m MultiPageViewModel) highlightMatches(text, query string) string {
 
 	return result.String()
 }
-
-// buildSettingsList creates the settings items list based on current options
-func buildSettingsList(options *OptionsDTO) []ListItem {
-	items := []ListItem{}
-
-	// Frequent GoTo setting
-	var frequentStatus string
-	if options.FrequentGoTo {
-		frequentStatus = "enabled ✓"
-	} else {
-		frequentStatus = "disabled ✗"
-	}
-
-	items = append(items, ListItem{
-		T:     "frequent_goTo",
-		D:     frequentStatus,
-		IsDiv: false,
-	})
-
-	// Clear Frequency History
-	items = append(items, ListItem{
-		T:     "clear_frequency",
-		D:     "clear all frequency history",
-		IsDiv: false,
-	})
-
-	return items
-}
@@ -0,0 +1,294 @@
+package src
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Note viewer layout
+const (
+	noteViewerDefaultWidth  = 80
+	noteViewerDefaultHeight = 20
+	noteViewerChrome        = 7 // Header (3), title (2), footer (2)
+)
+
+// noteViewer shows a note rendered as Markdown in a scrollable viewport
+type noteViewer struct {
+	item     ListItem
+	viewport viewport.Model
+}
+
+// buildNotesList lists the notes from config.json followed by the note files
+func buildNotesList(config *ConfigDTO) []ListItem {
+	items := ConfigItemsToListItems(config.Notes)
+	if len(config.NoteFiles) == 0 {
+		return items
+	}
+
+	if len(items) > 0 {
+		items = append(items, ListItem{T: "div", D: "📁 " + NotesDirName, IsDiv: true})
+	}
+	for _, note := range config.NoteFiles {
+		items = append(items, ListItem{
+			T:    note.Label,
+			D:    note.Content,
+			Path: note.Path,
+		})
+	}
+	return items
+}
+
+// hasNotes returns true when there is at least one note in config.json or on disk
+func hasNotes(config *ConfigDTO) bool {
+	return len(config.Notes.Keys) > 0 || len(config.NoteFiles) > 0
+}
+
+// RenderMarkdown renders text as Markdown wrapped to width, using a style
+// that matches the active theme
+func RenderMarkdown(text string, width int) (string, error) {
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(markdownStyle()),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return "", fmt.Errorf("RenderMarkdown -> %v", err)
+	}
+
+	out, err := renderer.Render(text)
+	if err != nil {
+		return "", fmt.Errorf("RenderMarkdown -> %v", err)
+	}
+	return strings.Trim(out, "\n"), nil
+}
+
+// markdownStyle picks the glamour style for the active theme
+func markdownStyle() string {
+	if os.Getenv("NO_COLOR") != "" {
+		return "notty"
+	}
+	switch ActiveThemeName() {
+	case LightThemeName:
+		return "light"
+	case MonochromeThemeName:
+		return "notty"
+	default:
+		return "dark"
+	}
+}
+
+// renderMarkdownCached renders a note, reusing earlier renders of the same text and width
+func (m MultiPageViewModel) renderMarkdownCached(text string, width int) string {
+	key := fmt.Sprintf("%s|%d|%s", ActiveThemeName(), width, text)
+	if rendered, ok := m.markdownCache[key]; ok {
+		return rendered
+	}
+
+	rendered, err := RenderMarkdown(text, width)
+	if err != nil {
+		// Fall back to the plain text
+		rendered = ansi.Wrap(text, width, " ")
+	}
+	m.markdownCache[key] = rendered
+	return rendered
+}
+
+// noteViewerSize returns the viewport size for the note viewer
+func (m MultiPageViewModel) noteViewerSize() (int, int) {
+	width, height := noteViewerDefaultWidth, noteViewerDefaultHeight
+	if m.width > 0 {
+		width = m.width - 2
+	}
+	if m.height > 0 {
+		height = m.height - noteViewerChrome
+	}
+	if height < 1 {
+		height = 1
+	}
+	return width, height
+}
+
+// openNoteViewer opens the selected note in the Markdown viewer
+func (m MultiPageViewModel) openNoteViewer() (tea.Model, tea.Cmd) {
+	item, ok := m.selectedItem()
+	if !ok || m.currentPage != NotesPage {
+		return m, nil
+	}
+	if IsSecret(item.D) {
+		m.errorMessage = fmt.Sprintf("Secret notes can't be viewed, press %s to copy", helpKeys(m.keys.Select))
+		return m, nil
+	}
+
+	width, height := m.noteViewerSize()
+	vp := viewport.New(width, height)
+	vp.KeyMap = m.keys.ViewportKeyMap()
+	vp.SetContent(m.renderMarkdownCached(item.D, width))
+
+	m.noteViewer = &noteViewer{item: item, viewport: vp}
+	return m, nil
+}
+
+// resizeNoteViewer fits the open viewer to the terminal and renders the note again
+func (m *MultiPageViewModel) resizeNoteViewer() {
+	width, height := m.noteViewerSize()
+	m.noteViewer.viewport.Width = width
+	m.noteViewer.viewport.Height = height
+	m.noteViewer.viewport.SetContent(m.renderMarkdownCached(m.noteViewer.item.D, width))
+}
+
+// updateNoteViewer scrolls the viewer, closes it, or copies the note
+func (m MultiPageViewModel) updateNoteViewer(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.ForceQuit):
+			*m.selected = Selection{Action: QuitAction}
+			m.quitting = true
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.View):
+			m.noteViewer = nil
+			return m, nil
+
+		case key.Matches(msg, m.keys.Select):
+			if m.actions != nil {
+				return m, m.actions.CopyToClipboard(m.noteViewer.item.D)
+			}
+			return m, nil
+		}
+	}
+
+	viewer := *m.noteViewer
+	var cmd tea.Cmd
+	viewer.viewport, cmd = viewer.viewport.Update(msg)
+	m.noteViewer = &viewer
+	return m, cmd
+}
+
+// renderNoteViewer renders the open note in place of the list
+func (m MultiPageViewModel) renderNoteViewer() string {
+	width, _ := m.noteViewerSize()
+	viewer := m.noteViewer
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.SelectedTitleColor)
+	scroll := m.styles.Text(fmt.Sprintf(" %3.f%%", viewer.viewport.ScrollPercent()*100), m.styles.MutedTitleColor)
+
+	var b strings.Builder
+	b.WriteString(" ")
+	b.WriteString(titleStyle.Render(ansi.Truncate(viewer.item.T, width-6, "…")))
+	b.WriteString(scroll)
+	b.WriteString("\n\n")
+	b.WriteString(viewer.viewport.View())
+	return b.String()
+}
+
+// Longest label suggested for a note created from the clipboard
+const suggestedLabelLength = 30
+
+// openNotePrompt asks for the label of a new note holding text
+func (m MultiPageViewModel) openNotePrompt(text string) (tea.Model, tea.Cmd) {
+	text = strings.TrimRight(text, "\n")
+	if strings.TrimSpace(text) == "" {
+		m.errorMessage = "The clipboard is empty"
+		return m, nil
+	}
+
+	label := new(string)
+	prompt := TextFieldViewModel("Label for the new note", "label", label)
+	prompt.textInput.SetValue(suggestNoteLabel(text))
+	prompt.textInput.CursorEnd()
+
+	m.notePrompt = &prompt
+	m.noteDraft = text
+	m.errorMessage = ""
+	return m, nil
+}
+
+// suggestNoteLabel uses the start of the first non-empty line as the label
+func suggestNoteLabel(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(line, "# "))
+		if line != "" {
+			return ansi.Truncate(line, suggestedLabelLength, "…")
+		}
+	}
+	return ""
+}
+
+// updateNotePrompt handles keys while the label prompt is open
+func (m MultiPageViewModel) updateNotePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.notePrompt = nil
+		m.noteDraft = ""
+		m.errorMessage = ""
+		return m, nil
+
+	case tea.KeyEnter:
+		label := strings.TrimSpace(m.notePrompt.textInput.Value())
+		if label == "" {
+			m.notePrompt.errors = true
+			return m, nil
+		}
+		if _, exists := m.config.Notes.Values[label]; exists {
+			m.notePrompt.errors = true
+			m.errorMessage = fmt.Sprintf("A note named %q already exists", label)
+			return m, nil
+		}
+		return m.addNote(label)
+	}
+
+	model, cmd := m.notePrompt.Update(msg)
+	prompt := model.(textInputViewModel)
+	m.notePrompt = &prompt
+	return m, cmd
+}
+
+// addNote appends the drafted note to config.json
+func (m MultiPageViewModel) addNote(label string) (tea.Model, tea.Cmd) {
+	if m.config.Notes.Values == nil {
+		m.config.Notes.Values = map[string]string{}
+	}
+	m.config.Notes.Keys = append(m.config.Notes.Keys, label)
+	m.config.Notes.Values[label] = m.noteDraft
+
+	m.notePrompt = nil
+	m.noteDraft = ""
+	m.errorMessage = ""
+	m.setConfig(m.config)
+
+	if m.actions != nil {
+		if err := m.actions.SaveConfig(m.config); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to save config: %v", err)
+			return m, nil
+		}
+	}
+	return m, func() tea.Msg {
+		return actionResultMsg{status: fmt.Sprintf("✓ Note %q added", label)}
+	}
+}
+
+// renderNotePrompt renders the label prompt with a summary of the new note
+func (m MultiPageViewModel) renderNotePrompt() string {
+	lines := strings.Split(m.noteDraft, "\n")
+	summary := ansi.Truncate(strings.TrimSpace(lines[0]), noteViewerDefaultWidth, "…")
+	if len(lines) > 1 {
+		summary += fmt.Sprintf(" ⏎ +%d lines", len(lines)-1)
+	}
+
+	var b strings.Builder
+	b.WriteString(m.notePrompt.View())
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.Text("  "+summary, m.styles.MutedTitleColor))
+	b.WriteString("\n")
+	if m.errorMessage != "" {
+		b.WriteString(m.renderFooterLine(m.errorMessage, m.styles.ErrorColor))
+	}
+	return b.String()
+}
@@ -2,10 +2,47 @@ package src
 
 type OptionsDTO struct {
 	FrequentGoTo bool `json:"frequent_goTo"`
+	// TrackVisits records cd's reported by the shell hook and suggests frequent directories
+	TrackVisits bool `json:"track_visits"`
+	// GoToAction is what Enter does with goTo items: cd, tmux-window, tmux-session or shell
+	GoToAction string `json:"goto_action"`
+	// GoToActions overrides GoToAction for single goTo labels
+	GoToActions map[string]string `json:"goto_actions,omitempty"`
+	// GlobMaxDepth is how many directory levels goTo patterns search
+	GlobMaxDepth int `json:"glob_max_depth"`
+	// CommandMode is where commands run: shell, once tg exits, or subprocess, inside tg
+	CommandMode string `json:"command_mode"`
+	FullScreen  bool   `json:"full_screen"`
+	Mouse       bool   `json:"mouse"`
+	KeyMap      string `json:"keymap"`
+	Theme       string `json:"theme"`
+	// Clipboard is the backend used to copy, "auto" tries them all
+	Clipboard string `json:"clipboard"`
+	// ClipboardHistory is how many copied values are kept, 0 turns the history off
+	ClipboardHistory int `json:"clipboard_history"`
+	// ClipboardClearSeconds is how long a copied secret stays on the clipboard, 0 keeps it
+	ClipboardClearSeconds int `json:"clipboard_clear_seconds"`
+	// Themes defines custom themes by name, next to the files in the themes directory
+	Themes map[string]ThemeDTO `json:"themes,omitempty"`
+	// RepoRoots are the directories scanned for git repositories
+	RepoRoots []string `json:"repo_roots,omitempty"`
+	// KeyBindings overrides the keys of single actions, e.g. {"up": ["up", "ctrl+k"]}
+	KeyBindings map[string][]string `json:"key_bindings,omitempty"`
 }
 
 func GetDefaultOptions() *OptionsDTO {
 	return &OptionsDTO{
-		FrequentGoTo: true,
+		FrequentGoTo:          true,
+		TrackVisits:           true,
+		GoToAction:            CdActionName,
+		GlobMaxDepth:          defaultGlobMaxDepth,
+		CommandMode:           ShellCommandMode,
+		FullScreen:            false,
+		Mouse:                 true,
+		KeyMap:                DefaultKeyMapName,
+		Theme:                 DarkThemeName,
+		Clipboard:             defaultClipboard,
+		ClipboardHistory:      defaultClipboardHistorySize,
+		ClipboardClearSeconds: defaultClipboardClearSeconds,
 	}
 }
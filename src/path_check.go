@@ -0,0 +1,173 @@
+package src
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How long to wait for goTo targets, network mounts may hang
+const pathCheckTimeout = 2 * time.Second
+
+// PathStatus is what is known about a goTo target
+type PathStatus int
+
+const (
+	// PathUnchecked is a target not checked yet, being checked, or that
+	// didn't answer in time
+	PathUnchecked PathStatus = iota
+	PathFound
+	PathMissing
+)
+
+// pathsCheckedMsg carries the status of goTo targets and the modification
+// time of the ones found, keyed by item value
+type pathsCheckedMsg struct {
+	statuses map[string]PathStatus
+	modTimes map[string]time.Time
+}
+
+type pathResult struct {
+	path    string
+	status  PathStatus
+	modTime time.Time
+}
+
+// CheckPaths checks all paths at once. Paths that don't answer within timeout
+// or can't be checked are left out, so they count as PathUnchecked.
+func CheckPaths(fm FileManagerInterface, paths []string, timeout time.Duration) map[string]PathStatus {
+	statuses, _ := checkPathTimes(fm, paths, timeout)
+	return statuses
+}
+
+// checkPathTimes is CheckPaths that also returns when the found paths last changed
+func checkPathTimes(fm FileManagerInterface, paths []string, timeout time.Duration) (map[string]PathStatus, map[string]time.Time) {
+	// Buffered so checks that outlive the timeout don't block forever
+	results := make(chan pathResult, len(paths))
+	for _, path := range paths {
+		go func(path string) {
+			modTime, exists, err := fm.GetPathModTime(path)
+			switch {
+			case err != nil:
+				results <- pathResult{path: path, status: PathUnchecked}
+			case exists:
+				results <- pathResult{path: path, status: PathFound, modTime: modTime}
+			default:
+				results <- pathResult{path: path, status: PathMissing}
+			}
+		}(path)
+	}
+
+	statuses := map[string]PathStatus{}
+	modTimes := map[string]time.Time{}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for range paths {
+		select {
+		case result := <-results:
+			if result.status != PathUnchecked {
+				statuses[result.path] = result.status
+			}
+			if result.status == PathFound {
+				modTimes[result.path] = result.modTime
+			}
+		case <-timer.C:
+			return statuses, modTimes
+		}
+	}
+	return statuses, modTimes
+}
+
+// goToTarget returns the path a goTo value points at. Values running a
+// command can't be checked without running it, and values with a reference
+// that doesn't resolve here, like an unset variable, point nowhere known.
+func goToTarget(value string) (string, bool) {
+	if strings.Contains(value, cmdRefPrefix) {
+		return "", false
+	}
+	path, err := expandPathReferences(value, false)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// CheckGoToValues checks where each goTo value points, in the background
+func (a *Actions) CheckGoToValues(values []string) tea.Cmd {
+	return func() tea.Msg {
+		targets := map[string][]string{}
+		paths := []string{}
+		for _, value := range values {
+			path, ok := goToTarget(value)
+			if !ok {
+				continue
+			}
+			if _, seen := targets[path]; !seen {
+				paths = append(paths, path)
+			}
+			targets[path] = append(targets[path], value)
+		}
+
+		msg := pathsCheckedMsg{statuses: map[string]PathStatus{}, modTimes: map[string]time.Time{}}
+		statuses, modTimes := checkPathTimes(a.fileManager, paths, pathCheckTimeout)
+		for path, status := range statuses {
+			for _, value := range targets[path] {
+				msg.statuses[value] = status
+				if modTime, ok := modTimes[path]; ok {
+					msg.modTimes[value] = modTime
+				}
+			}
+		}
+		return msg
+	}
+}
+
+// checkPaths starts checking the goTo values that weren't checked yet
+func (m MultiPageViewModel) checkPaths() tea.Cmd {
+	if m.actions == nil || m.quitting {
+		return nil
+	}
+
+	values := []string{}
+	for _, items := range [][]ListItem{m.goToList, m.frequentList} {
+		for _, item := range items {
+			if item.IsDiv {
+				continue
+			}
+			// The map is shared with later copies of the model, like the preview cache
+			if _, ok := m.pathStatus[item.D]; !ok {
+				m.pathStatus[item.D] = PathUnchecked
+				values = append(values, item.D)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return m.actions.CheckGoToValues(values)
+}
+
+// isMissing returns true for goTo items whose directory doesn't exist
+func (m MultiPageViewModel) isMissing(item ListItem) bool {
+	return m.isGoToPage() && !item.IsDiv && m.pathStatus[item.D] == PathMissing
+}
+
+// isGoToSelection returns true for selections whose value is a directory
+func isGoToSelection(selection Selection) bool {
+	return selection.Page == GoToPage || selection.Page == FrequentPage || selection.Page == ReposPage
+}
+
+// checkSelectedPath refuses a goTo selection whose directory doesn't exist,
+// so the shell isn't left with a failing cd
+func (a *Actions) checkSelectedPath(selection Selection) error {
+	if !isGoToSelection(selection) {
+		return nil
+	}
+	path := selection.Value
+	if CheckPaths(a.fileManager, []string{path}, pathCheckTimeout)[path] == PathMissing {
+		return fmt.Errorf("%s doesn't exist, edit the entry or run tg doctor", path)
+	}
+	return nil
+}
@@ -0,0 +1,362 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Preview pane layout
+const (
+	previewMinWidth     = 80 // Terminal width needed to show the preview pane
+	previewMaxEntries   = 200
+	previewGitTimeout   = 2 * time.Second
+	previewListFraction = 2 // The list takes 1/previewListFraction of the width
+)
+
+// DirPreview holds the directory contents and git state shown for a goTo item
+type DirPreview struct {
+	Path      string
+	Entries   []string
+	Truncated bool
+	Branch    string
+	Changes   int
+	IsRepo    bool
+	// When the directory last changed as of this read
+	ModTime time.Time
+	Err     error
+}
+
+type dirPreviewMsg struct {
+	key     string
+	preview DirPreview
+}
+
+// previewEnabled returns true when the two-column full screen layout is active
+func (m MultiPageViewModel) previewEnabled() bool {
+	return m.options.FullScreen && m.width >= previewMinWidth
+}
+
+// listWidth returns the width available to the list column
+func (m MultiPageViewModel) listWidth() int {
+	if m.previewEnabled() {
+		return m.width / previewListFraction
+	}
+	return m.width
+}
+
+// previewWidth returns the width available to the preview column
+func (m MultiPageViewModel) previewWidth() int {
+	return m.width - m.listWidth() - 1
+}
+
+// selectedItem returns the item under the cursor, if any
+func (m MultiPageViewModel) selectedItem() (ListItem, bool) {
+	items := m.getActiveList()
+	if m.cursor < 0 || m.cursor >= len(items) || items[m.cursor].IsDiv {
+		return ListItem{}, false
+	}
+	return items[m.cursor], true
+}
+
+// isGoToPage returns true for pages whose items are directories
+func (m MultiPageViewModel) isGoToPage() bool {
+	return m.currentPage == GoToPage || m.currentPage == FrequentPage || m.currentPage == ReposPage
+}
+
+// loadPreview starts loading the directory preview for the selected goTo item
+func (m MultiPageViewModel) loadPreview() tea.Cmd {
+	if m.quitting || !m.previewEnabled() || !m.isGoToPage() {
+		return nil
+	}
+
+	item, ok := m.selectedItem()
+	if !ok {
+		return nil
+	}
+
+	// The cache is a map so the pending marker is shared with later copies of
+	// the model. A preview older than the last path check is read again, and
+	// stays on screen stamped with the new time until the read is done.
+	key := item.D
+	preview, ok := m.dirPreviews[key]
+	modTime, checked := m.pathModTimes[key]
+	if ok && (preview == nil || !checked || !modTime.After(preview.ModTime)) {
+		return nil
+	}
+	if ok {
+		stale := *preview
+		stale.ModTime = modTime
+		m.dirPreviews[key] = &stale
+	} else {
+		m.dirPreviews[key] = nil
+	}
+
+	// Previews never run the commands of $(cmd: ...) references
+	path, err := expandPathReferences(item.D, false)
+	if err != nil {
+		path = item.D
+	}
+	return func() tea.Msg {
+		return dirPreviewMsg{key: key, preview: ReadDirPreview(path)}
+	}
+}
+
+// recheckPreview checks the selected directory again, a newer modification
+// time reloads its preview
+func (m MultiPageViewModel) recheckPreview() tea.Cmd {
+	if m.actions == nil || m.quitting || !m.previewEnabled() || !m.isGoToPage() {
+		return nil
+	}
+
+	item, ok := m.selectedItem()
+	if !ok {
+		return nil
+	}
+	return m.actions.CheckGoToValues([]string{item.D})
+}
+
+// ReadDirPreview lists a directory and reads its git branch and status
+func ReadDirPreview(path string) DirPreview {
+	preview := DirPreview{Path: path}
+
+	// Stat first, a change made while listing is picked up by the next check
+	info, err := os.Stat(path)
+	if err != nil {
+		preview.Err = err
+		return preview
+	}
+	preview.ModTime = info.ModTime()
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		preview.Err = err
+		return preview
+	}
+
+	// Directories first, then files, both alphabetical
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].IsDir() != entries[j].IsDir() {
+			return entries[i].IsDir()
+		}
+		return strings.ToLower(entries[i].Name()) < strings.ToLower(entries[j].Name())
+	})
+
+	for _, entry := range entries {
+		if len(preview.Entries) >= previewMaxEntries {
+			preview.Truncated = true
+			break
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			name += "/"
+		}
+		preview.Entries = append(preview.Entries, name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), previewGitTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "git", "-C", path, "status", "--porcelain", "--branch").Output()
+	if err != nil {
+		return preview
+	}
+
+	preview.IsRepo = true
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	for _, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			preview.Branch = strings.TrimPrefix(line, "## ")
+			continue
+		}
+		if line != "" {
+			preview.Changes++
+		}
+	}
+
+	return preview
+}
+
+// renderPreview renders the preview column for the selected item
+func (m MultiPageViewModel) renderPreview() string {
+	width := m.previewWidth()
+	// Header (3) + footer (3) + border (2)
+	height := m.height - 8
+	if height < 1 {
+		height = 1
+	}
+
+	var content string
+	item, ok := m.selectedItem()
+	switch {
+	case !ok:
+		content = m.styles.Text("Nothing selected", m.styles.MutedTitleColor)
+	case m.isGoToPage():
+		content = m.renderDirPreview(item, width-4)
+	case m.currentPage == CommandsPage:
+		content = m.renderCommandPreview(item, width-4)
+	case m.currentPage == NotesPage:
+		content = m.renderNotePreview(item, width-4)
+	default:
+		content = m.renderTextPreview(item, width-4)
+	}
+
+	// Clip to the available height so the layout never scrolls
+	lines := strings.Split(content, "\n")
+	if len(lines) > height {
+		lines = append(lines[:height-1], m.styles.Text("…", m.styles.MutedTitleColor))
+	}
+
+	return lipgloss.NewStyle().
+		Border(m.styles.Border).
+		BorderForeground(m.styles.MutedBorderColor).
+		Padding(0, 1).
+		Width(width - 2).
+		Height(height).
+		Render(strings.Join(lines, "\n"))
+}
+
+func (m MultiPageViewModel) renderPreviewTitle(title string, width int) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.SelectedTitleColor)
+	return titleStyle.Render(ansi.Truncate(title, width, "…"))
+}
+
+func (m MultiPageViewModel) renderDirPreview(item ListItem, width int) string {
+	var b strings.Builder
+	b.WriteString(m.renderPreviewTitle(item.T, width))
+	b.WriteString("\n")
+
+	preview, ok := m.dirPreviews[item.D]
+	if !ok || preview == nil {
+		b.WriteString(m.styles.Text("Loading…", m.styles.MutedTitleColor))
+		return b.String()
+	}
+
+	b.WriteString(m.styles.Text(ansi.Truncate("📁 "+preview.Path, width, "…"), m.styles.FooterColor))
+	b.WriteString("\n")
+
+	if preview.Err != nil {
+		b.WriteString(m.styles.Text(ansi.Wrap(preview.Err.Error(), width, ""), m.styles.ErrorColor))
+		return b.String()
+	}
+
+	if preview.IsRepo {
+		status := "clean"
+		if preview.Changes > 0 {
+			status = fmt.Sprintf("%d changed", preview.Changes)
+		}
+		gitLine := fmt.Sprintf(" %s · %s", preview.Branch, status)
+		b.WriteString(m.styles.Text(ansi.Truncate(gitLine, width, "…"), m.styles.AquamarineColor))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if len(preview.Entries) == 0 {
+		b.WriteString(m.styles.Text("(empty)", m.styles.MutedTitleColor))
+	}
+	for _, entry := range preview.Entries {
+		color := m.styles.TitleColor
+		if strings.HasSuffix(entry, "/") {
+			color = m.styles.PeachColor
+		}
+		b.WriteString(m.styles.Text(ansi.Truncate(entry, width, "…"), color))
+		b.WriteString("\n")
+	}
+	if preview.Truncated {
+		b.WriteString(m.styles.Text("…", m.styles.MutedTitleColor))
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (m MultiPageViewModel) renderCommandPreview(item ListItem, width int) string {
+	highlighted := HighlightShell(item.D, m.styles)
+	return m.renderPreviewTitle(item.T, width) + "\n\n" + ansi.Wrap(highlighted, width, " ")
+}
+
+func (m MultiPageViewModel) renderNotePreview(item ListItem, width int) string {
+	if IsSecret(item.D) {
+		return m.renderPreviewTitle(item.T, width) + "\n\n" + m.styles.Text(SecretMask, m.styles.MutedTitleColor)
+	}
+	return m.renderPreviewTitle(item.T, width) + "\n\n" + m.renderMarkdownCached(item.D, width)
+}
+
+func (m MultiPageViewModel) renderTextPreview(item ListItem, width int) string {
+	text := m.styles.Text(ansi.Wrap(item.D, width, " "), m.styles.SettingsValueColor)
+	return m.renderPreviewTitle(item.T, width) + "\n\n" + text
+}
+
+// HighlightShell colors a shell command: command names, flags, strings, variables and operators
+func HighlightShell(command string, styles *Styles) string {
+	var b strings.Builder
+	commandPosition := true
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case r == ' ' || r == '\t' || r == '\n':
+			b.WriteRune(r)
+			i++
+
+		case r == '\'' || r == '"':
+			// Quoted string, up to the matching quote
+			j := i + 1
+			for j < len(runes) && runes[j] != r {
+				if runes[j] == '\\' && r == '"' {
+					j++
+				}
+				j++
+			}
+			if j < len(runes) {
+				j++
+			}
+			b.WriteString(styles.Text(string(runes[i:j]), styles.NyanzaColor))
+			commandPosition = false
+			i = j
+
+		case strings.ContainsRune("|&;<>()", r):
+			// Operators, a command follows pipes and separators
+			j := i
+			for j < len(runes) && strings.ContainsRune("|&;<>()", runes[j]) {
+				j++
+			}
+			op := string(runes[i:j])
+			b.WriteString(styles.Text(op, styles.CoralColor))
+			commandPosition = !strings.ContainsAny(op, "<>")
+			i = j
+
+		default:
+			// A plain word, up to the next space, quote or operator
+			j := i
+			for j < len(runes) && !strings.ContainsRune(" \t\n'\"|&;<>()", runes[j]) {
+				j++
+			}
+			word := string(runes[i:j])
+
+			switch {
+			case strings.HasPrefix(word, "$"):
+				b.WriteString(styles.Text(word, styles.PeachColor))
+			case strings.HasPrefix(word, "-"):
+				b.WriteString(styles.Text(word, styles.ThistleColor))
+			case commandPosition && !strings.Contains(word, "="):
+				b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(styles.AquamarineColor).Render(word))
+				commandPosition = false
+			default:
+				b.WriteString(styles.Text(word, styles.FooterColor))
+			}
+			i = j
+		}
+	}
+
+	return b.String()
+}
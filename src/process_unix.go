@@ -0,0 +1,25 @@
+//go:build !windows
+
+package src
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts cmd in a process group of its own, so signals sent to
+// the terminal's group don't reach it
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// lockFile waits for an exclusive lock on f, released when f is closed
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// stopProcessGroup sends SIGTERM to the group started by detachProcess
+func stopProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package src
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts cmd in a process group of its own, so ctrl+c in the
+// console doesn't reach it
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// lockFile is a no-op, the shell hooks that track visits concurrently only
+// exist for unix shells
+func lockFile(f *os.File) error {
+	return nil
+}
+
+// stopProcessGroup kills the process, Windows has no signal to ask it to stop
+func stopProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
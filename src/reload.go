@@ -0,0 +1,237 @@
+package src
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How often the app directory is checked for changes
+const reloadInterval = time.Second
+
+// fileStamp identifies one version of a file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// FileStamps maps the watched files to their last known version
+type FileStamps map[string]fileStamp
+
+// filesCheckedMsg carries the state of the watched files after a check
+type filesCheckedMsg struct {
+	stamps FileStamps
+}
+
+// reloadMsg carries the files read again after a change. Nil fields did not change.
+type reloadMsg struct {
+	config        *ConfigDTO
+	options       *OptionsDTO
+	goToFrequency *GoToFrequencyDTO
+	err           error
+}
+
+// SnapshotFiles stats the files the view is built from. Files in the notes
+// and themes directories are included so adding or removing one is noticed.
+func (a *Actions) SnapshotFiles() FileStamps {
+	fm := a.fileManager.(*FileManager)
+	stamps := FileStamps{}
+
+	add := func(path string) {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+
+	add(fm.ConfigPath)
+	add(fm.OptionsPath)
+	add(fm.GoToFrequencyPath)
+	for _, dir := range []string{fm.NotesDir, fm.ThemesDir} {
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			add(filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	return stamps
+}
+
+// WatchFiles checks the app directory once reloadInterval has passed
+func (a *Actions) WatchFiles() tea.Cmd {
+	return tea.Tick(reloadInterval, func(time.Time) tea.Msg {
+		return filesCheckedMsg{stamps: a.SnapshotFiles()}
+	})
+}
+
+// Reload reads the files that changed between two snapshots
+func (a *Actions) Reload(before, after FileStamps) tea.Cmd {
+	fm := a.fileManager.(*FileManager)
+
+	changed := func(paths ...string) bool {
+		for path := range mergeKeys(before, after) {
+			for _, p := range paths {
+				inDir := filepath.Dir(path) == p
+				if (path == p || inDir) && before[path] != after[path] {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	configChanged := changed(fm.ConfigPath, fm.NotesDir)
+	optionsChanged := changed(fm.OptionsPath, fm.ThemesDir)
+	frequencyChanged := changed(fm.GoToFrequencyPath)
+
+	return func() tea.Msg {
+		var msg reloadMsg
+		var err error
+
+		if configChanged {
+			if msg.config, err = a.LoadConfig(); err != nil {
+				return reloadMsg{err: err}
+			}
+		}
+
+		if optionsChanged {
+			if msg.options, err = a.LoadOptions(); err != nil {
+				return reloadMsg{err: err}
+			}
+		}
+
+		if frequencyChanged {
+			content, err := a.fileManager.GetGoToFrequencyContent()
+			if err != nil {
+				return reloadMsg{err: fmt.Errorf("Failed to read goTo frequency: %v", err)}
+			}
+			msg.goToFrequency = GetDefaultGoToFrequency()
+			if content != "" {
+				if msg.goToFrequency, err = ParseJSONContent[GoToFrequencyDTO](content); err != nil {
+					return reloadMsg{err: fmt.Errorf("Failed to parse %s: %v", GoToFrequencyFileName, err)}
+				}
+			}
+		}
+
+		return msg
+	}
+}
+
+// mergeKeys returns the paths present in either snapshot
+func mergeKeys(a, b FileStamps) map[string]bool {
+	keys := map[string]bool{}
+	for path := range a {
+		keys[path] = true
+	}
+	for path := range b {
+		keys[path] = true
+	}
+	return keys
+}
+
+// equalStamps returns true when no watched file changed
+func equalStamps(a, b FileStamps) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, stamp := range a {
+		if b[path] != stamp {
+			return false
+		}
+	}
+	return true
+}
+
+// checkFiles starts a reload when a watched file changed and keeps watching.
+// The selected directory is checked along, so its preview follows changes.
+func (m MultiPageViewModel) checkFiles(msg filesCheckedMsg) (tea.Model, tea.Cmd) {
+	if m.actions == nil {
+		return m, nil
+	}
+
+	before := m.fileStamps
+	m.fileStamps = msg.stamps
+	if before == nil || equalStamps(before, msg.stamps) {
+		return m, tea.Batch(m.actions.WatchFiles(), m.recheckPreview())
+	}
+	return m, tea.Batch(m.actions.Reload(before, msg.stamps), m.actions.WatchFiles(), m.recheckPreview())
+}
+
+// applyReload swaps in the files read again. Parse errors leave the current
+// state on screen and show a banner until the file is fixed.
+func (m MultiPageViewModel) applyReload(msg reloadMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.errorMessage = msg.err.Error()
+		return m, nil
+	}
+	m.errorMessage = ""
+
+	var cmds []tea.Cmd
+	if msg.options != nil {
+		cmds = append(cmds, m.setOptions(msg.options))
+	}
+	if msg.goToFrequency != nil {
+		*m.goToFrequency = *msg.goToFrequency
+		m.rebuildPages()
+	}
+	if msg.config != nil {
+		m.setConfig(msg.config)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// setOptions applies options read from disk to the running view
+func (m *MultiPageViewModel) setOptions(options *OptionsDTO) tea.Cmd {
+	previous := *m.options
+	*m.options = *options
+
+	if m.actions != nil {
+		if err := m.actions.LoadThemes(m.options); err != nil {
+			m.errorMessage = err.Error()
+		}
+	}
+	if err := ValidateKeyBindings(m.options); err != nil {
+		m.errorMessage = err.Error()
+	}
+
+	// Run the same updates as a change made on the settings page
+	var cmds []tea.Cmd
+	for _, setting := range Settings() {
+		if setting.OnChange == nil || !settingChanged(setting, &previous, m.options) {
+			continue
+		}
+		cmds = append(cmds, setting.OnChange(m))
+	}
+	// Repositories found under other roots don't belong on the page anymore
+	if !slices.Equal(previous.RepoRoots, m.options.RepoRoots) {
+		if m.repoCache != nil {
+			m.setRepoCache(m.repoCache)
+		}
+		cmds = append(cmds, m.scanRepos())
+	}
+	// Themes may have changed on disk even when the name didn't
+	SetActiveTheme(m.options.Theme)
+	m.styles = DefaultStyles()
+	m.keys = NewKeyMap(m.options)
+	m.settingsList = buildSettingsList(m.options)
+
+	return tea.Batch(cmds...)
+}
+
+// settingChanged compares the value of a setting in two sets of options
+func settingChanged(setting Setting, a, b *OptionsDTO) bool {
+	switch setting.Type {
+	case BoolSetting:
+		return *setting.Bool(a) != *setting.Bool(b)
+	case IntSetting:
+		return *setting.Int(a) != *setting.Int(b)
+	case EnumSetting, StringSetting:
+		return *setting.String(a) != *setting.String(b)
+	default:
+		return false
+	}
+}
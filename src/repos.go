@@ -0,0 +1,371 @@
+package src
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// Levels below a repo root searched for repositories
+	repoScanMaxDepth = 4
+	// How long git may take to report the state of one repository
+	repoStatusTimeout = 5 * time.Second
+	// Repositories whose state is read at once
+	repoStatusWorkers = 8
+)
+
+// RepoDTO is a git repository found under one of the repo roots
+type RepoDTO struct {
+	Path string `json:"path"`
+	// Root is the repo root the repository was found under
+	Root   string `json:"root"`
+	Branch string `json:"branch"`
+	Dirty  bool   `json:"dirty"`
+	Ahead  int    `json:"ahead"`
+	Behind int    `json:"behind"`
+	// Worktree is a linked worktree, whose .git is a file
+	Worktree bool `json:"worktree,omitempty"`
+	// Unknown is set when git couldn't report the state, only the branch is known
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// RepoCacheDTO is the last scan of the repo roots, shown while the next one runs
+type RepoCacheDTO struct {
+	Roots     []string  `json:"roots"`
+	ScannedAt time.Time `json:"scanned_at"`
+	Repos     []RepoDTO `json:"repos"`
+}
+
+func GetDefaultRepoCache() *RepoCacheDTO {
+	return &RepoCacheDTO{
+		Roots: []string{},
+		Repos: []RepoDTO{},
+	}
+}
+
+// reposScannedMsg carries a finished scan of the repo roots
+type reposScannedMsg struct {
+	cache *RepoCacheDTO
+	err   error
+}
+
+// ScanRepos finds the git repositories under roots, with the linked worktrees
+// of each, and reads their state
+func ScanRepos(roots []string) []RepoDTO {
+	repos := []RepoDTO{}
+	seen := map[string]bool{}
+	add := func(path, root string, worktree bool) {
+		if !seen[path] {
+			seen[path] = true
+			repos = append(repos, RepoDTO{Path: path, Root: root, Worktree: worktree})
+		}
+	}
+
+	for _, root := range roots {
+		for _, path := range findRepos(root, repoScanMaxDepth) {
+			gitDir, worktree := repoGitDir(path)
+			add(path, root, worktree)
+			for _, linked := range linkedWorktrees(gitDir) {
+				add(linked, root, true)
+			}
+		}
+	}
+
+	// Read the state of several repositories at once, git status may be slow
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	for range repoStatusWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				readRepoStatus(&repos[i])
+			}
+		}()
+	}
+	for i := range repos {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(repos, func(i, j int) bool {
+		if repos[i].Root != repos[j].Root {
+			return slices.Index(roots, repos[i].Root) < slices.Index(roots, repos[j].Root)
+		}
+		return repos[i].Path < repos[j].Path
+	})
+	return repos
+}
+
+// findRepos returns the directories under root holding a .git entry, without
+// looking inside repositories or hidden directories
+func findRepos(root string, maxDepth int) []string {
+	repos := []string{}
+	var walk func(dir string, depth int)
+	walk = func(dir string, depth int) {
+		if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+			repos = append(repos, dir)
+			return
+		}
+		if depth >= maxDepth {
+			return
+		}
+		for _, name := range subdirectories(dir) {
+			if !strings.HasPrefix(name, ".") {
+				walk(filepath.Join(dir, name), depth+1)
+			}
+		}
+	}
+	walk(filepath.Clean(root), 0)
+	return repos
+}
+
+// repoGitDir returns the git directory of a repository. A .git file points to
+// it instead, as in linked worktrees.
+func repoGitDir(path string) (string, bool) {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil || info.IsDir() {
+		return dotGit, false
+	}
+
+	content, err := os.ReadFile(dotGit)
+	if err != nil {
+		return dotGit, true
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !ok {
+		return dotGit, true
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+	return gitDir, true
+}
+
+// linkedWorktrees lists the worktrees registered in a git directory that
+// still exist
+func linkedWorktrees(gitDir string) []string {
+	entries, err := os.ReadDir(filepath.Join(gitDir, "worktrees"))
+	if err != nil {
+		return nil
+	}
+	paths := []string{}
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(gitDir, "worktrees", entry.Name(), "gitdir"))
+		if err != nil {
+			continue
+		}
+		// gitdir holds the path of the worktree's .git file
+		path := filepath.Dir(strings.TrimSpace(string(content)))
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// readHeadBranch reads the checked out branch from HEAD, or the short commit
+// hash when HEAD is detached
+func readHeadBranch(gitDir string) string {
+	content, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	head := strings.TrimSpace(string(content))
+	if ref, ok := strings.CutPrefix(head, "ref:"); ok {
+		return strings.TrimPrefix(strings.TrimSpace(ref), "refs/heads/")
+	}
+	if len(head) > 7 {
+		head = head[:7]
+	}
+	return head
+}
+
+// readRepoStatus fills in the branch and the dirty, ahead and behind state of
+// a repository. git compares against the upstream as last fetched, nothing
+// goes over the network.
+func readRepoStatus(repo *RepoDTO) {
+	gitDir, _ := repoGitDir(repo.Path)
+	repo.Branch = readHeadBranch(gitDir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), repoStatusTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "-C", repo.Path, "--no-optional-locks",
+		"status", "--porcelain=v2", "--branch")
+	out, err := cmd.Output()
+	if err != nil {
+		repo.Unknown = true
+		return
+	}
+	parseRepoStatus(repo, string(out))
+}
+
+// parseRepoStatus reads the output of git status --porcelain=v2 --branch
+func parseRepoStatus(repo *RepoDTO, output string) {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		header, ok := strings.CutPrefix(line, "# ")
+		if !ok {
+			// Every other line is a changed or untracked file
+			if line != "" {
+				repo.Dirty = true
+			}
+			continue
+		}
+
+		fields := strings.Fields(header)
+		switch {
+		case len(fields) == 2 && fields[0] == "branch.head" && fields[1] != "(detached)":
+			repo.Branch = fields[1]
+		case len(fields) == 3 && fields[0] == "branch.ab":
+			repo.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "+"))
+			repo.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "-"))
+		}
+	}
+}
+
+// repoState summarizes the branch and state of a repository, e.g. "⎇ main ● ↑1 ↓2"
+func repoState(repo RepoDTO) string {
+	parts := []string{"⎇ " + repo.Branch}
+	if repo.Unknown {
+		parts = append(parts, "?")
+	}
+	if repo.Dirty {
+		parts = append(parts, "●")
+	}
+	if repo.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", repo.Ahead))
+	}
+	if repo.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", repo.Behind))
+	}
+	return strings.Join(parts, " ")
+}
+
+// buildReposList lists the repositories of the cache, in a section per root
+// when there are several
+func buildReposList(cache *RepoCacheDTO) []ListItem {
+	items := []ListItem{}
+	root := ""
+	for _, repo := range cache.Repos {
+		if repo.Root != root && len(cache.Roots) > 1 {
+			items = append(items, ListItem{T: "div", D: "📁 " + abbreviateHome(repo.Root), IsDiv: true})
+		}
+		root = repo.Root
+
+		name, err := filepath.Rel(repo.Root, repo.Path)
+		if err != nil || name == "." || strings.HasPrefix(name, "..") {
+			// The root itself or a worktree kept elsewhere
+			name = filepath.Base(repo.Path)
+		}
+		items = append(items, ListItem{
+			T: fmt.Sprintf("%s  %s", filepath.ToSlash(name), repoState(repo)),
+			D: abbreviateHome(repo.Path),
+		})
+	}
+	return items
+}
+
+// repoRoots expands the repo roots of the options
+func repoRoots(options *OptionsDTO, u UtilsInterface) []string {
+	roots := []string{}
+	for _, root := range options.RepoRoots {
+		roots = append(roots, filepath.Clean(u.ExpandPath(root)))
+	}
+	return roots
+}
+
+// LoadRepoCache reads the last repository scan
+func (a *Actions) LoadRepoCache() (*RepoCacheDTO, error) {
+	content, err := a.fileManager.GetRepoCacheContent()
+	if err != nil {
+		return nil, fmt.Errorf("LoadRepoCache -> %v", err)
+	}
+	if content == "" {
+		return GetDefaultRepoCache(), nil
+	}
+
+	cache, err := ParseJSONContent[RepoCacheDTO](content)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: %v", RepoCacheFileName, err)
+	}
+	return cache, nil
+}
+
+// SaveRepoCache writes the repository scan
+func (a *Actions) SaveRepoCache(cache *RepoCacheDTO) error {
+	jsonStr, err := ToJSON(cache)
+	if err != nil {
+		return fmt.Errorf("SaveRepoCache -> %v", err)
+	}
+	if err := a.fileManager.WriteRepoCacheContent(jsonStr); err != nil {
+		return fmt.Errorf("SaveRepoCache -> %v", err)
+	}
+	return nil
+}
+
+// ScanRepos scans the repo roots in the background and caches the result
+func (a *Actions) ScanRepos() tea.Cmd {
+	roots := repoRoots(a.options, a.utils)
+	if len(roots) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		cache := &RepoCacheDTO{Roots: roots, ScannedAt: time.Now(), Repos: ScanRepos(roots)}
+		if err := a.SaveRepoCache(cache); err != nil {
+			return reposScannedMsg{cache: cache, err: err}
+		}
+		return reposScannedMsg{cache: cache}
+	}
+}
+
+// setRepoCache shows a repository scan, unless it was made for other roots
+func (m *MultiPageViewModel) setRepoCache(cache *RepoCacheDTO) {
+	m.repoCache = cache
+	m.reposList = nil
+	if m.actions != nil && slices.Equal(cache.Roots, repoRoots(m.options, m.actions.utils)) {
+		m.reposList = buildReposList(cache)
+	}
+	m.rebuildPages()
+
+	if m.currentPage == ReposPage {
+		if m.searchMode {
+			m.updateFilteredList()
+		}
+		items := m.getActiveList()
+		if m.cursor >= len(items) {
+			m.cursor = len(items) - 1
+		}
+		if m.cursor < 0 {
+			m.cursor = 0
+		}
+		for m.cursor < len(items)-1 && items[m.cursor].IsDiv {
+			m.cursor++
+		}
+		m.clampViewport()
+	}
+}
+
+// scanRepos starts scanning the repo roots when some are configured
+func (m MultiPageViewModel) scanRepos() tea.Cmd {
+	if m.actions == nil {
+		return nil
+	}
+	return m.actions.ScanRepos()
+}
@@ -0,0 +1,190 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// References resolved when an item is selected, never at load time
+const (
+	envRefPrefix = "${env:" // ${env:NAME}
+	cmdRefPrefix = "$(cmd:" // $(cmd: command)
+
+	// How long a $(cmd: ...) reference may run
+	cmdRefTimeout = 30 * time.Second
+)
+
+// selectionResolvedMsg carries a selection once its value was resolved
+type selectionResolvedMsg struct {
+	selection Selection
+	err       error
+	// missing is the value of a goTo item whose directory doesn't exist
+	missing string
+}
+
+// ResolveValue replaces ${env:NAME} with the environment variable and
+// $(cmd: command) with the output of the command
+func (u *Utils) ResolveValue(value string) (string, error) {
+	return resolveReferences(value, true)
+}
+
+// ResolvePath resolves the references in a goTo value and expands ~ and
+// environment variables in the text around them, so neither the value of a
+// reference nor the output of a command is expanded again
+func (u *Utils) ResolvePath(value string) (string, error) {
+	return expandPathReferences(value, true)
+}
+
+// expandPathReferences resolves the references of a path and expands ~ and
+// $VAR in its literal text only. Command references are kept as written
+// unless runCommands is set. A $VAR that is unset and has no default is an
+// error, like ${env:VAR}, rather than an empty part of the path.
+func expandPathReferences(value string, runCommands bool) (string, error) {
+	unset := ""
+	path, err := replaceReferences(value, runCommands, func(literal string) string {
+		return os.Expand(literal, func(name string) string {
+			variable, _, hasFallback := strings.Cut(name, ":-")
+			if _, ok := os.LookupEnv(variable); !ok && !hasFallback && unset == "" {
+				unset = variable
+			}
+			return expandVariable(name)
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	if unset != "" {
+		return "", fmt.Errorf("environment variable %s is not set", unset)
+	}
+	if strings.HasPrefix(value, "~") {
+		path = expandTilde(path)
+	}
+	return path, nil
+}
+
+func resolveReferences(value string, runCommands bool) (string, error) {
+	return replaceReferences(value, runCommands, nil)
+}
+
+// replaceReferences resolves the references of value, passing the text
+// between them through literal when it is set
+func replaceReferences(value string, runCommands bool, literal func(string) string) (string, error) {
+	var b strings.Builder
+	rest := value
+	writeLiteral := func(text string) {
+		if literal != nil {
+			text = literal(text)
+		}
+		b.WriteString(text)
+	}
+
+	for {
+		envIndex := strings.Index(rest, envRefPrefix)
+		cmdIndex := strings.Index(rest, cmdRefPrefix)
+		if envIndex < 0 && cmdIndex < 0 {
+			writeLiteral(rest)
+			return b.String(), nil
+		}
+
+		if cmdIndex < 0 || (envIndex >= 0 && envIndex < cmdIndex) {
+			writeLiteral(rest[:envIndex])
+			rest = rest[envIndex+len(envRefPrefix):]
+
+			end := strings.Index(rest, "}")
+			if end < 0 {
+				return "", fmt.Errorf("unterminated %s reference", envRefPrefix+"…}")
+			}
+			name := strings.TrimSpace(rest[:end])
+			envValue, ok := os.LookupEnv(name)
+			if !ok {
+				return "", fmt.Errorf("environment variable %s is not set", name)
+			}
+			b.WriteString(envValue)
+			rest = rest[end+1:]
+			continue
+		}
+
+		writeLiteral(rest[:cmdIndex])
+		reference := rest[cmdIndex:]
+		rest = rest[cmdIndex+len(cmdRefPrefix):]
+
+		end := matchingParen(rest)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated %s reference", cmdRefPrefix+"…)")
+		}
+		command := strings.TrimSpace(rest[:end])
+		rest = rest[end+1:]
+
+		if !runCommands {
+			b.WriteString(reference[:len(cmdRefPrefix)+end+1])
+			continue
+		}
+		output, err := runReferenceCommand(command)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(output)
+	}
+}
+
+// matchingParen returns the index of the parenthesis closing an already open one
+func matchingParen(s string) int {
+	depth := 1
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// runReferenceCommand runs a $(cmd: ...) command and returns its output
+// without the trailing newline, like shell command substitution
+func runReferenceCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cmdRefTimeout)
+	defer cancel()
+
+	var stderr strings.Builder
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%q failed: %s", command, msg)
+		}
+		return "", fmt.Errorf("%q failed: %v", command, err)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// Resolve fills in the value of a selection that leaves the TUI, so
+// references are resolved while errors can still be shown
+func (a *Actions) Resolve(selection Selection) tea.Cmd {
+	return func() tea.Msg {
+		resolve := a.utils.ResolveValue
+		if isGoToSelection(selection) {
+			resolve = a.utils.ResolvePath
+		}
+		value, err := resolve(selection.Item.D)
+		if err != nil {
+			return selectionResolvedMsg{err: fmt.Errorf("Failed to resolve %s: %v", selection.Item.T, err)}
+		}
+		selection.Value = value
+		if err := a.checkSelectedPath(selection); err != nil {
+			return selectionResolvedMsg{err: err, missing: selection.Item.D}
+		}
+		return selectionResolvedMsg{selection: selection}
+	}
+}
@@ -0,0 +1,95 @@
+package src
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandPathReferences(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Setenv("TG_TEST_DIR", "/srv/work")
+	t.Setenv("TG_TEST_EMPTY", "")
+	t.Setenv("TG_TEST_DOLLAR", "/srv/$HOME")
+	t.Setenv("TG_TEST_UNSET", "")
+	os.Unsetenv("TG_TEST_UNSET") // Restored by t.Setenv
+
+	tests := []struct {
+		name        string
+		value       string
+		runCommands bool
+		want        string
+		wantErr     bool
+	}{
+		{name: "plain", value: "/srv/app", want: "/srv/app"},
+		{name: "tilde", value: "~/src", want: filepath.Join(home, "src")},
+		{name: "tilde alone", value: "~", want: home},
+		{name: "tilde not leading", value: "/srv/~/x", want: "/srv/~/x"},
+		{name: "variable", value: "$TG_TEST_DIR/api", want: "/srv/work/api"},
+		{name: "braced variable", value: "${TG_TEST_DIR}/api", want: "/srv/work/api"},
+		{name: "default used", value: "${TG_TEST_UNSET:-/tmp}/api", want: "/tmp/api"},
+		{name: "default for empty", value: "${TG_TEST_EMPTY:-/tmp}", want: "/tmp"},
+		{name: "default with tilde", value: "${TG_TEST_DIR:-~/x}", want: "/srv/work"},
+		{name: "set but empty", value: "$TG_TEST_EMPTY/api", want: "/api"},
+		{name: "unset variable", value: "$TG_TEST_UNSET/api", wantErr: true},
+		{name: "unset braced variable", value: "/srv/${TG_TEST_UNSET}", wantErr: true},
+		{name: "env reference", value: "${env:TG_TEST_DIR}/api", want: "/srv/work/api"},
+		{name: "env reference unset", value: "${env:TG_TEST_UNSET}/api", wantErr: true},
+		{name: "env reference unterminated", value: "${env:TG_TEST_DIR/api", wantErr: true},
+		{name: "env value not expanded again", value: "${env:TG_TEST_DOLLAR}", want: "/srv/$HOME"},
+		{name: "literal dollar", value: "/srv/price$/x", want: "/srv/price$/x"},
+		{name: "command kept", value: "$(cmd: echo /srv)/api", want: "$(cmd: echo /srv)/api"},
+		{name: "command run", value: "$(cmd: echo /srv/$((1+1)))/api", runCommands: true, want: "/srv/2/api"},
+		{name: "command output not expanded", value: "$(cmd: echo '$HOME')", runCommands: true, want: "$HOME"},
+		{name: "command failing", value: "$(cmd: exit 3)", runCommands: true, wantErr: true},
+		{name: "command unterminated", value: "$(cmd: echo /srv", runCommands: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandPathReferences(tt.value, tt.runCommands)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Setenv("TG_TEST_DIR", "/srv/work")
+	t.Setenv("TG_TEST_UNSET", "")
+	os.Unsetenv("TG_TEST_UNSET") // Restored by t.Setenv
+
+	tests := map[string]string{
+		"~/src":                  filepath.Join(home, "src"),
+		"$TG_TEST_DIR/api":       "/srv/work/api",
+		"${TG_TEST_UNSET:-/tmp}": "/tmp",
+		"~no-such-user-tg/x":     "~no-such-user-tg/x",
+	}
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		tests["~"+current.Username+"/src"] = filepath.Join(current.HomeDir, "src")
+	}
+
+	u := NewUtils()
+	for value, want := range tests {
+		if got := u.ExpandPath(value); got != want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
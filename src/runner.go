@@ -2,13 +2,27 @@ package src
 
 import (
 	"fmt"
-	"strings"
+	"os"
+	"path/filepath"
 )
 
+const usage = `Usage:
+  tg                    open the TUI
+  tg import <source>    import goTo entries from zoxide, autojump, z or fasd
+  tg import history     save frequent shell commands as Commands
+  tg add-here [label]   save the current directory to goTo
+  tg doctor [--prune]   list goTo entries whose directory is gone, --prune removes them
+  tg init <shell>       print the integration for bash, zsh or fish
+  tg track <dir>        record a visit to a directory, used by the shell hook
+  tg help               show this help
+`
+
 type Runner struct {
 	fileManager FileManagerInterface
 	utils       UtilsInterface
 	viewBuilder ViewBuilderInterface
+	// dirActions opens goTo directories in tmux or a new shell
+	dirActions DirActionRunner
 }
 
 func NewRunner(fm FileManagerInterface, u UtilsInterface, b ViewBuilderInterface) *Runner {
@@ -16,12 +30,124 @@ func NewRunner(fm FileManagerInterface, u UtilsInterface, b ViewBuilderInterface
 		fileManager: fm,
 		utils:       u,
 		viewBuilder: b,
+		dirActions:  NewExecDirActionRunner(),
+	}
+}
+
+// Run dispatches a subcommand, or opens the TUI when there is none
+func (r *Runner) Run(args []string) {
+	if len(args) == 0 {
+		r.Start()
+		return
+	}
+
+	switch args[0] {
+	case "import":
+		r.Import(args[1:])
+	case "add-here":
+		r.AddHere(args[1:])
+	case "doctor":
+		r.Doctor(args[1:])
+	case "init":
+		r.Init(args[1:])
+	case "track":
+		// Called by the shell hook on every cd, stays quiet
+		if err := r.Track(args[1:]); err != nil {
+			os.Exit(1)
+		}
+	case ClearClipboardCommand:
+		// Started in the background after a secret is copied
+		if err := RunClipboardClearer(args[1:], os.Stdin); err != nil {
+			os.Exit(1)
+		}
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+	default:
+		r.utils.ExitWithError(fmt.Sprintf("Unknown command %q\n\n%s", args[0], usage))
 	}
 }
 
 func (r *Runner) Start() {
+	options, config, goToFrequency := r.load()
 	styles := DefaultStyles()
+	actions := NewActions(r.fileManager, r.utils, options)
+
+	// Check if all pages are empty
+	if len(config.GoTo.Keys) == 0 && len(config.Commands.Keys) == 0 && !hasNotes(config) && len(options.RepoRoots) == 0 {
+		println(styles.Text("\n⚠️  All pages are empty!", styles.ErrorColor))
+		println(styles.Text("\nPlease edit your config file:", styles.TitleColor))
+		println(styles.Text("  "+r.fileManager.(*FileManager).ConfigPath, styles.FooterColor))
+		println()
+		return
+	}
+
+	// Show multi-page view
+	selection := r.viewBuilder.NewMultiPageView(config, options, goToFrequency, actions)
+	if selection.Action == QuitAction {
+		return
+	}
+
+	// Only actions that leave the shell end the view, the others ran inside it
+	switch selection.Page {
+	case GoToPage, FrequentPage, ReposPage:
+		// Resolve already expanded ~ and environment variables, and kept the
+		// view open on a missing directory
+		expandedPath := selection.Value
+
+		// Increment goTo frequency counter if it's a goTo navigation. Suggestions
+		// and repositories aren't in goTo, the shell hook counts their visits.
+		if options.FrequentGoTo && selection.Page != ReposPage && !selection.Item.Suggestion {
+			r.updateGoToFrequency(func(goToFrequency *GoToFrequencyDTO) {
+				goToFrequency.IncrementGoTo(selection.Item.T)
+			})
+		}
+
+		if selection.Action == OpenAction {
+			// Paths come from the filesystem too, quote them for the shell that evals this
+			r.writeShellCommand("cd " + shellQuote(expandedPath))
+			return
+		}
+
+		// Repository labels carry their state, name windows after the directory
+		name := selection.Item.T
+		if selection.Page == ReposPage {
+			name = filepath.Base(expandedPath)
+		}
+		if err := r.dirActions.Run(selection.Action, name, expandedPath); err != nil {
+			r.utils.HandleError(err, fmt.Sprintf("Failed to open %s", expandedPath))
+		}
 
+	case CommandsPage, HistoryPage:
+		if selection.Action == InsertAction {
+			r.writeShellInsert(selection.Value)
+			return
+		}
+		// Run the command in the calling shell
+		r.writeShellCommand(selection.Value)
+	}
+}
+
+// writeShellCommand writes a command for the shell wrapper to eval once the
+// binary exits
+func (r *Runner) writeShellCommand(command string) {
+	cmdFile := r.fileManager.(*FileManager).AppDir + "/cmd-exec"
+	if err := r.fileManager.WriteFileContent(cmdFile, command); err != nil {
+		r.utils.HandleError(err, "Failed to write command file")
+	}
+}
+
+// writeShellInsert writes a command for the shell wrapper to put on the
+// prompt, where it can be edited before it runs
+func (r *Runner) writeShellInsert(command string) {
+	insertFile := r.fileManager.(*FileManager).AppDir + "/cmd-insert"
+	if err := r.fileManager.WriteFileContent(insertFile, command); err != nil {
+		r.utils.HandleError(err, "Failed to write command file")
+	}
+}
+
+// load reads options, config and goTo frequency, creating the files with
+// defaults when they are empty, and applies the selected theme
+func (r *Runner) load() (*OptionsDTO, *ConfigDTO, *GoToFrequencyDTO) {
 	// Initialize application directory and config file
 	if err := r.fileManager.BasicSetup(); err != nil {
 		r.utils.HandleError(err, "Failed to initialize application")
@@ -45,12 +171,24 @@ func (r *Runner) Start() {
 			r.utils.HandleError(err, "Failed to write default options")
 		}
 	} else {
-		options, err = ParseJSONContent[OptionsDTO](optionsContent)
+		// Options missing from the file keep their default values
+		options, err = ParseJSONContentWithDefaults(optionsContent, GetDefaultOptions())
 		if err != nil {
 			r.utils.HandleError(err, "Failed to parse options.json")
 		}
 	}
 
+	if err := ValidateKeyBindings(options); err != nil {
+		r.utils.HandleError(err, "Failed to load options")
+	}
+
+	// Register custom themes and apply the selected one
+	actions := NewActions(r.fileManager, r.utils, options)
+	if err := actions.LoadThemes(options); err != nil {
+		r.utils.HandleError(err, "Failed to load themes")
+	}
+	SetActiveTheme(options.Theme)
+
 	// Load or create default config
 	configContent, err := r.fileManager.GetConfigContent()
 	if err != nil {
@@ -75,6 +213,13 @@ func (r *Runner) Start() {
 		}
 	}
 
+	// Notes kept as files in the notes directory
+	config.NoteFiles, err = r.fileManager.GetNoteFiles()
+	if err != nil {
+		r.utils.HandleError(err, "Failed to read notes")
+	}
+	expandGoToGlobs(config, options.GlobMaxDepth)
+
 	// Load or create default goTo frequency
 	goToFreqContent, err := r.fileManager.GetGoToFrequencyContent()
 	if err != nil {
@@ -83,15 +228,8 @@ func (r *Runner) Start() {
 
 	var goToFrequency *GoToFrequencyDTO
 	if goToFreqContent == "" {
-		// Create default goTo frequency
-		goToFrequency = GetDefaultGoToFrequency()
-		jsonStr, err := ToJSON(goToFrequency)
-		if err != nil {
-			r.utils.HandleError(err, "Failed to create default goTo frequency")
-		}
-		if err := r.fileManager.WriteGoToFrequencyContent(jsonStr); err != nil {
-			r.utils.HandleError(err, "Failed to write default goTo frequency")
-		}
+		// Create default goTo frequency, unless tg track got there first
+		goToFrequency = r.updateGoToFrequency(func(*GoToFrequencyDTO) {})
 	} else {
 		goToFrequency, err = ParseJSONContent[GoToFrequencyDTO](goToFreqContent)
 		if err != nil {
@@ -99,102 +237,26 @@ func (r *Runner) Start() {
 		}
 	}
 
-	// Check if all pages are empty
-	if len(config.GoTo.Keys) == 0 && len(config.Commands.Keys) == 0 && len(config.Notes.Keys) == 0 {
-		println(styles.Text("\n⚠️  All pages are empty!", styles.ErrorColor))
-		println(styles.Text("\nPlease edit your config file:", styles.TitleColor))
-		println(styles.Text("  "+r.fileManager.(*FileManager).ConfigPath, styles.FooterColor))
-		println()
-		return
-	}
-
-	// Show multi-page view
-	result := r.viewBuilder.NewMultiPageView(config, options, goToFrequency)
-	r.utils.ValidateInput(result)
+	return options, config, goToFrequency
+}
 
-	// Parse result: "page|label|value"
-	parts := strings.Split(result, "|")
-	if len(parts) != 3 {
-		return
+// saveConfig writes config.json
+func (r *Runner) saveConfig(config *ConfigDTO) {
+	jsonStr, err := ToJSON(config)
+	if err != nil {
+		r.utils.HandleError(err, "Failed to serialize config")
 	}
+	if err := r.fileManager.WriteConfigContent(jsonStr); err != nil {
+		r.utils.HandleError(err, "Failed to write config")
+	}
+}
 
-	page := parts[0]
-	label := parts[1]
-	value := parts[2]
-
-	// Handle based on page type
-	switch page {
-	case "settings":
-		// Handle settings toggle
-		switch label {
-		case "frequent_goTo":
-			// Toggle the frequent_goTo option
-			options.FrequentGoTo = !options.FrequentGoTo
-
-			// Save the updated options
-			jsonStr, err := ToJSON(options)
-			if err != nil {
-				r.utils.HandleError(err, "Failed to serialize options")
-			}
-			if err := r.fileManager.WriteOptionsContent(jsonStr); err != nil {
-				r.utils.HandleError(err, "Failed to write options")
-			}
-
-			var statusMsg string
-			if options.FrequentGoTo {
-				statusMsg = "✓ Frequent GoTo enabled"
-			} else {
-				statusMsg = "✓ Frequent GoTo disabled"
-			}
-			println(styles.Text(statusMsg, styles.AquamarineColor))
-
-		case "clear_frequency":
-			// Clear the frequency history
-			emptyFrequency := GetDefaultGoToFrequency()
-			jsonStr, err := ToJSON(emptyFrequency)
-			if err != nil {
-				r.utils.HandleError(err, "Failed to serialize goTo frequency")
-			}
-			if err := r.fileManager.WriteGoToFrequencyContent(jsonStr); err != nil {
-				r.utils.HandleError(err, "Failed to write goTo frequency")
-			}
-
-			println(styles.Text("✓ Frequency history cleared", styles.AquamarineColor))
-		}
-
-	case "goTo", "frequent":
-		// Increment goTo frequency counter if it's a goTo navigation
-		if options.FrequentGoTo {
-			goToFrequency.IncrementGoTo(label)
-			jsonStr, err := ToJSON(goToFrequency)
-			if err != nil {
-				r.utils.HandleError(err, "Failed to serialize goTo frequency")
-			}
-			if err := r.fileManager.WriteGoToFrequencyContent(jsonStr); err != nil {
-				r.utils.HandleError(err, "Failed to write goTo frequency")
-			}
-		}
-
-		// Expand ~ to home directory
-		expandedPath := r.utils.ExpandPath(value)
-
-		// Write cd command to file
-		cmdFile := r.fileManager.(*FileManager).AppDir + "/cmd-exec"
-		command := fmt.Sprintf("cd %s", expandedPath)
-
-		if err := r.fileManager.WriteFileContent(cmdFile, command); err != nil {
-			r.utils.HandleError(err, "Failed to write command file")
-		}
-
-	case "commands":
-		println(styles.Text("\n⚠️  Commands execution not implemented yet", styles.ErrorColor))
-
-	case "notes":
-		// Copy value to clipboard
-		if err := r.utils.CopyToClipboard(value); err != nil {
-			r.utils.HandleError(err, "Failed to copy to clipboard")
-		}
-
-		println(styles.Text("✓ Copied to clipboard: "+value, styles.AquamarineColor))
+// updateGoToFrequency changes goto_frequency.json as it is on disk, see
+// UpdateGoToFrequency, and returns what was saved
+func (r *Runner) updateGoToFrequency(update func(*GoToFrequencyDTO)) *GoToFrequencyDTO {
+	goToFrequency, err := UpdateGoToFrequency(r.fileManager, update)
+	if err != nil {
+		r.utils.HandleError(err, "Failed to write goTo frequency")
 	}
+	return goToFrequency
 }
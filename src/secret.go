@@ -0,0 +1,233 @@
+package src
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Secret values are stored as secretPrefix followed by base64 of salt,
+// nonce and AES-256-GCM ciphertext. The key is derived from a passphrase.
+const (
+	secretPrefix   = "enc:v1:"
+	secretSaltSize = 16
+	secretKeySize  = 32
+
+	// SecretMask replaces secret values wherever they would be shown
+	SecretMask = "••••"
+
+	// PassphraseEnv can hold the passphrase so tg doesn't ask for it
+	PassphraseEnv = "TG_PASSPHRASE"
+)
+
+// PBKDF2 rounds for the secret key, a variable so tests can lower it
+var secretIterations = 600000
+
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// IsSecret returns true for values encrypted by EncryptSecret
+func IsSecret(value string) bool {
+	return strings.HasPrefix(value, secretPrefix)
+}
+
+// EncryptSecret encrypts plain with a key derived from passphrase
+func EncryptSecret(plain, passphrase string) (string, error) {
+	salt := make([]byte, secretSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("EncryptSecret -> %v", err)
+	}
+
+	gcm, err := secretCipher(passphrase, salt)
+	if err != nil {
+		return "", fmt.Errorf("EncryptSecret -> %v", err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("EncryptSecret -> %v", err)
+	}
+
+	data := append(salt, nonce...)
+	data = gcm.Seal(data, nonce, []byte(plain), nil)
+	return secretPrefix + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// DecryptSecret reverses EncryptSecret. A wrong passphrase returns ErrWrongPassphrase.
+func DecryptSecret(value, passphrase string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, secretPrefix))
+	if err != nil {
+		return "", fmt.Errorf("DecryptSecret -> %v", err)
+	}
+	if len(data) < secretSaltSize {
+		return "", fmt.Errorf("DecryptSecret -> value is too short")
+	}
+
+	salt := data[:secretSaltSize]
+	gcm, err := secretCipher(passphrase, salt)
+	if err != nil {
+		return "", fmt.Errorf("DecryptSecret -> %v", err)
+	}
+
+	data = data[secretSaltSize:]
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("DecryptSecret -> value is too short")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		// GCM can't tell a wrong key from tampered data, the key is far more likely
+		return "", ErrWrongPassphrase
+	}
+	return string(plain), nil
+}
+
+func secretCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, secretIterations, secretKeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// secretAction is what runs once the passphrase is known
+type secretAction int
+
+const (
+	copySecretAction secretAction = iota
+	toggleSecretAction
+)
+
+// secretToggledMsg carries a note value after it was encrypted or decrypted
+type secretToggledMsg struct {
+	key   string
+	value string
+	err   error
+}
+
+// CopySecret decrypts a secret, copies it and schedules the clipboard to be
+// cleared. Secrets never enter the clipboard history.
+func (a *Actions) CopySecret(value, passphrase string) tea.Cmd {
+	return func() tea.Msg {
+		plain, err := DecryptSecret(value, passphrase)
+		if err != nil {
+			return actionResultMsg{err: fmt.Errorf("Failed to decrypt the note: %w", err)}
+		}
+		if err := a.utils.CopyToClipboard(plain, a.options.Clipboard); err != nil {
+			return actionResultMsg{err: fmt.Errorf("Failed to copy to clipboard: %v", err)}
+		}
+
+		seconds := a.options.ClipboardClearSeconds
+		if seconds <= 0 {
+			return actionResultMsg{status: "✓ Secret copied to clipboard"}
+		}
+		if err := ScheduleClipboardClear(plain, a.options.Clipboard, seconds); err != nil {
+			return actionResultMsg{err: fmt.Errorf("Copied, but the clipboard won't be cleared: %v", err)}
+		}
+		return actionResultMsg{status: fmt.Sprintf("✓ Secret copied, clipboard clears in %ds", seconds)}
+	}
+}
+
+// ToggleSecret encrypts a plain note value or decrypts a secret one
+func (a *Actions) ToggleSecret(key, value, passphrase string) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if IsSecret(value) {
+			value, err = DecryptSecret(value, passphrase)
+		} else {
+			value, err = EncryptSecret(value, passphrase)
+		}
+		if err != nil {
+			return secretToggledMsg{err: fmt.Errorf("Failed to change the note: %w", err)}
+		}
+		return secretToggledMsg{key: key, value: value}
+	}
+}
+
+// runSecretAction starts action on item, asking for the passphrase first
+// when it isn't known yet
+func (m MultiPageViewModel) runSecretAction(action secretAction, item ListItem) (tea.Model, tea.Cmd) {
+	if m.actions == nil {
+		return m, nil
+	}
+	if item.Path != "" {
+		m.errorMessage = "Only notes in config.json can be secret"
+		return m, nil
+	}
+
+	if m.passphrase == "" {
+		return m.openPassphrasePrompt(action, item)
+	}
+
+	m.errorMessage = ""
+	switch action {
+	case copySecretAction:
+		return m, m.actions.CopySecret(item.D, m.passphrase)
+	default:
+		return m, m.actions.ToggleSecret(item.T, item.D, m.passphrase)
+	}
+}
+
+// openPassphrasePrompt asks for the passphrase, then runs action on item
+func (m MultiPageViewModel) openPassphrasePrompt(action secretAction, item ListItem) (tea.Model, tea.Cmd) {
+	value := new(string)
+	prompt := TextFieldViewModel("Passphrase for secret notes", "passphrase", value)
+	prompt.textInput.EchoMode = textinput.EchoPassword
+	prompt.textInput.EchoCharacter = '•'
+
+	m.passphrasePrompt = &prompt
+	m.pendingSecret = &pendingSecret{action: action, item: item}
+	m.errorMessage = ""
+	return m, nil
+}
+
+// pendingSecret is the action waiting for the passphrase prompt
+type pendingSecret struct {
+	action secretAction
+	item   ListItem
+}
+
+// updatePassphrasePrompt handles keys while the passphrase prompt is open
+func (m MultiPageViewModel) updatePassphrasePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.passphrasePrompt = nil
+		m.pendingSecret = nil
+		return m, nil
+
+	case tea.KeyEnter:
+		passphrase := m.passphrasePrompt.textInput.Value()
+		if passphrase == "" {
+			m.passphrasePrompt.errors = true
+			return m, nil
+		}
+		pending := *m.pendingSecret
+		m.passphrase = passphrase
+		m.passphrasePrompt = nil
+		m.pendingSecret = nil
+		return m.runSecretAction(pending.action, pending.item)
+	}
+
+	model, cmd := m.passphrasePrompt.Update(msg)
+	prompt := model.(textInputViewModel)
+	m.passphrasePrompt = &prompt
+	return m, cmd
+}
+
+// forgetWrongPassphrase drops the cached passphrase after it failed to decrypt
+func (m *MultiPageViewModel) forgetWrongPassphrase(err error) {
+	if errors.Is(err, ErrWrongPassphrase) {
+		m.passphrase = ""
+	}
+}
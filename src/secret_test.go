@@ -0,0 +1,86 @@
+package src
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fastSecrets lowers the key derivation rounds for the length of a test
+func fastSecrets(t *testing.T) {
+	t.Helper()
+	iterations := secretIterations
+	secretIterations = 1000
+	t.Cleanup(func() { secretIterations = iterations })
+}
+
+func TestSecretRoundTrip(t *testing.T) {
+	fastSecrets(t)
+
+	for _, plain := range []string{"hunter2", "", "multi\nline ✓"} {
+		secret, err := EncryptSecret(plain, "correct horse")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !IsSecret(secret) || !strings.HasPrefix(secret, secretPrefix) {
+			t.Fatalf("%q is not marked as a secret", secret)
+		}
+		if plain != "" && strings.Contains(secret, plain) {
+			t.Fatalf("%q holds the plain text", secret)
+		}
+
+		got, err := DecryptSecret(secret, "correct horse")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != plain {
+			t.Errorf("got %q, want %q", got, plain)
+		}
+	}
+}
+
+func TestSecretSaltDiffers(t *testing.T) {
+	fastSecrets(t)
+
+	first, _ := EncryptSecret("hunter2", "pass")
+	second, _ := EncryptSecret("hunter2", "pass")
+	if first == second {
+		t.Error("encrypting twice gave the same value")
+	}
+}
+
+func TestSecretWrongPassphrase(t *testing.T) {
+	fastSecrets(t)
+
+	secret, err := EncryptSecret("hunter2", "right")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptSecret(secret, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("got %v, want ErrWrongPassphrase", err)
+	}
+}
+
+func TestDecryptSecretMalformed(t *testing.T) {
+	fastSecrets(t)
+
+	secret, _ := EncryptSecret("hunter2", "pass")
+	tests := map[string]string{
+		"not base64":   secretPrefix + "!!!",
+		"too short":    secretPrefix + "AAAA",
+		"no nonce":     secretPrefix + "AAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+		"tampered":     secret[:len(secret)-4] + "AAA=",
+		"plain prefix": "hunter2",
+	}
+	for name, value := range tests {
+		if _, err := DecryptSecret(value, "pass"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestIsSecret(t *testing.T) {
+	if IsSecret("enc:v2:abc") || IsSecret("my enc:v1: note") || !IsSecret("enc:v1:abc") {
+		t.Error("only values starting with enc:v1: are secrets")
+	}
+}
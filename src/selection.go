@@ -0,0 +1,49 @@
+package src
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// SelectionAction is what the user asked to do when the multi-page view ended
+type SelectionAction int
+
+const (
+	// QuitAction means the view was closed without selecting anything
+	QuitAction SelectionAction = iota
+	// OpenAction is the page's default action: cd for goTo, run for commands
+	OpenAction
+	// TmuxWindowAction opens a goTo directory in a new tmux window
+	TmuxWindowAction
+	// TmuxSessionAction switches to the tmux session named after a goTo
+	// label, creating it in the directory
+	TmuxSessionAction
+	// ShellAction starts a new shell in a goTo directory
+	ShellAction
+	// InsertAction puts a command on the shell prompt without running it
+	InsertAction
+)
+
+// Modifiers records the modifier keys held when the selection was made
+type Modifiers struct {
+	Alt   bool
+	Ctrl  bool
+	Shift bool
+}
+
+// Selection is the result of the multi-page view
+type Selection struct {
+	Page PageType
+	Item ListItem
+	// Value is the item value with ${env:...} and $(cmd: ...) resolved
+	Value     string
+	Action    SelectionAction
+	Modifiers Modifiers
+}
+
+// keyModifiers returns the modifiers of a key press
+func keyModifiers(msg tea.KeyMsg) Modifiers {
+	return Modifiers{Alt: msg.Alt}
+}
+
+// mouseModifiers returns the modifiers of a mouse click
+func mouseModifiers(msg tea.MouseMsg) Modifiers {
+	return Modifiers{Alt: msg.Alt, Ctrl: msg.Ctrl, Shift: msg.Shift}
+}
@@ -0,0 +1,395 @@
+package src
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type SettingType int
+
+const (
+	BoolSetting SettingType = iota
+	EnumSetting
+	IntSetting
+	StringSetting
+	// ActionSetting runs an action instead of editing a value
+	ActionSetting
+	// JSONSetting is a list or map shown read-only, edited in options.json
+	JSONSetting
+)
+
+// Setting declares an option shown on the settings page. Each type uses the
+// matching accessor, which returns a pointer into OptionsDTO.
+type Setting struct {
+	Key         string
+	Description string
+	Type        SettingType
+
+	Bool   func(o *OptionsDTO) *bool
+	Int    func(o *OptionsDTO) *int
+	String func(o *OptionsDTO) *string // Used by enum and string settings
+
+	Choices  func() []string // Enum values
+	Min, Max int             // Int range
+
+	Summary func(o *OptionsDTO) string // JSON settings, empty when not set
+
+	// Inactive tells why the setting has no effect with the other options,
+	// empty when it applies. Such settings are shown greyed out.
+	Inactive func(o *OptionsDTO) string
+
+	// OnChange applies the new value to the running view
+	OnChange func(m *MultiPageViewModel) tea.Cmd
+}
+
+// Settings returns every setting in the order shown on the settings page
+func Settings() []Setting {
+	return []Setting{
+		{
+			Key:         "frequent_goTo",
+			Description: "show the most visited goTo entries first",
+			Type:        BoolSetting,
+			Bool:        func(o *OptionsDTO) *bool { return &o.FrequentGoTo },
+			OnChange: func(m *MultiPageViewModel) tea.Cmd {
+				m.rebuildPages()
+				return nil
+			},
+		},
+		{
+			Key:         "track_visits",
+			Description: "record directories visited in the shell and suggest frequent ones",
+			Type:        BoolSetting,
+			Bool:        func(o *OptionsDTO) *bool { return &o.TrackVisits },
+			OnChange: func(m *MultiPageViewModel) tea.Cmd {
+				m.rebuildPages()
+				return nil
+			},
+		},
+		{
+			Key:         "goto_action",
+			Description: "what enter does with goTo entries",
+			Type:        EnumSetting,
+			String:      func(o *OptionsDTO) *string { return &o.GoToAction },
+			Choices:     DirActionNames,
+		},
+		{
+			Key:         "glob_max_depth",
+			Description: "directory levels searched by goTo patterns",
+			Type:        IntSetting,
+			Int:         func(o *OptionsDTO) *int { return &o.GlobMaxDepth },
+			Min:         1,
+			Max:         10,
+			OnChange: func(m *MultiPageViewModel) tea.Cmd {
+				expandGoToGlobs(m.config, m.options.GlobMaxDepth)
+				m.setConfig(m.config)
+				return nil
+			},
+		},
+		{
+			Key:         "command_mode",
+			Description: "run commands in the shell once tg exits, or inside tg",
+			Type:        EnumSetting,
+			String:      func(o *OptionsDTO) *string { return &o.CommandMode },
+			Choices:     CommandModes,
+		},
+		{
+			Key:         "full_screen",
+			Description: "alternate screen with a preview pane",
+			Type:        BoolSetting,
+			Bool:        func(o *OptionsDTO) *bool { return &o.FullScreen },
+			OnChange: func(m *MultiPageViewModel) tea.Cmd {
+				m.updateLayout()
+				if m.options.FullScreen {
+					cmds := []tea.Cmd{tea.EnterAltScreen}
+					if m.options.Mouse {
+						cmds = append(cmds, tea.EnableMouseCellMotion)
+					}
+					return tea.Batch(cmds...)
+				}
+				return tea.Batch(tea.ExitAltScreen, tea.DisableMouse)
+			},
+		},
+		{
+			Key:         "mouse",
+			Description: "mouse support in full screen",
+			Type:        BoolSetting,
+			Bool:        func(o *OptionsDTO) *bool { return &o.Mouse },
+			// Clicks are reported by screen row, the inline view doesn't know where it starts
+			Inactive: func(o *OptionsDTO) string {
+				if !o.FullScreen {
+					return "needs full_screen"
+				}
+				return ""
+			},
+			OnChange: func(m *MultiPageViewModel) tea.Cmd {
+				if m.options.Mouse && m.options.FullScreen {
+					return tea.EnableMouseCellMotion
+				}
+				return tea.DisableMouse
+			},
+		},
+		{
+			Key:         "theme",
+			Description: "color scheme",
+			Type:        EnumSetting,
+			String:      func(o *OptionsDTO) *string { return &o.Theme },
+			Choices:     ThemeNames,
+			OnChange: func(m *MultiPageViewModel) tea.Cmd {
+				SetActiveTheme(m.options.Theme)
+				m.styles = DefaultStyles()
+				return nil
+			},
+		},
+		{
+			Key:         "keymap",
+			Description: "key binding preset",
+			Type:        EnumSetting,
+			String:      func(o *OptionsDTO) *string { return &o.KeyMap },
+			Choices: func() []string {
+				return []string{DefaultKeyMapName, VimKeyMapName, EmacsKeyMapName}
+			},
+			OnChange: func(m *MultiPageViewModel) tea.Cmd {
+				m.keys = NewKeyMap(m.options)
+				return nil
+			},
+		},
+		{
+			Key:         "clipboard",
+			Description: "how notes are copied",
+			Type:        EnumSetting,
+			String:      func(o *OptionsDTO) *string { return &o.Clipboard },
+			Choices:     ClipboardBackends,
+		},
+		{
+			Key:         "clipboard_history",
+			Description: "number of copied values to keep",
+			Type:        IntSetting,
+			Int:         func(o *OptionsDTO) *int { return &o.ClipboardHistory },
+			Min:         0,
+			Max:         100,
+			OnChange: func(m *MultiPageViewModel) tea.Cmd {
+				m.clipboardHistory.Trim(m.options.ClipboardHistory)
+				m.saveClipboardHistory()
+				return nil
+			},
+		},
+		{
+			Key:         "clipboard_clear_seconds",
+			Description: "seconds before a copied secret is cleared, 0 keeps it",
+			Type:        IntSetting,
+			Int:         func(o *OptionsDTO) *int { return &o.ClipboardClearSeconds },
+			Min:         0,
+			Max:         3600,
+		},
+		{
+			Key:         "goto_actions",
+			Description: "goto_action for single goTo labels",
+			Type:        JSONSetting,
+			Summary:     func(o *OptionsDTO) string { return summarizeKeys(o.GoToActions) },
+		},
+		{
+			Key:         "repo_roots",
+			Description: "directories scanned for git repositories",
+			Type:        JSONSetting,
+			Summary: func(o *OptionsDTO) string {
+				return strings.Join(o.RepoRoots, ", ")
+			},
+		},
+		{
+			Key:         "key_bindings",
+			Description: "keys of single actions",
+			Type:        JSONSetting,
+			Summary:     func(o *OptionsDTO) string { return summarizeKeys(o.KeyBindings) },
+		},
+		{
+			Key:         "themes",
+			Description: "custom themes",
+			Type:        JSONSetting,
+			Summary:     func(o *OptionsDTO) string { return summarizeKeys(o.Themes) },
+		},
+		{
+			Key:         "clear_frequency",
+			Description: "clear all frequency history",
+			Type:        ActionSetting,
+		},
+	}
+}
+
+// summarizeKeys lists the keys of a map option in order
+func summarizeKeys[V any](values map[string]V) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
+
+// findSetting returns the setting with the given key
+func findSetting(key string) (Setting, bool) {
+	for _, setting := range Settings() {
+		if setting.Key == key {
+			return setting, true
+		}
+	}
+	return Setting{}, false
+}
+
+// inactiveReason returns why the setting has no effect, empty when it applies
+func (s Setting) inactiveReason(o *OptionsDTO) string {
+	if s.Inactive == nil {
+		return ""
+	}
+	return s.Inactive(o)
+}
+
+// DisplayValue formats the current value of a setting for the settings page
+func (s Setting) DisplayValue(o *OptionsDTO) string {
+	if reason := s.inactiveReason(o); reason != "" {
+		return s.value(o) + "  ‹" + reason + "›"
+	}
+	return s.value(o)
+}
+
+func (s Setting) value(o *OptionsDTO) string {
+	switch s.Type {
+	case BoolSetting:
+		if *s.Bool(o) {
+			return "enabled ✓"
+		}
+		return "disabled ✗"
+	case IntSetting:
+		return strconv.Itoa(*s.Int(o))
+	case EnumSetting:
+		return fmt.Sprintf("%s  ‹%s›", *s.String(o), strings.Join(s.Choices(), " · "))
+	case StringSetting:
+		if *s.String(o) == "" {
+			return "(not set)"
+		}
+		return *s.String(o)
+	case JSONSetting:
+		if summary := s.Summary(o); summary != "" {
+			return summary + "  ‹edit options.json›"
+		}
+		return "(not set)  ‹edit options.json›"
+	default:
+		return s.Description
+	}
+}
+
+// buildSettingsList creates the settings items list based on current options
+func buildSettingsList(options *OptionsDTO) []ListItem {
+	items := []ListItem{}
+	for _, setting := range Settings() {
+		items = append(items, ListItem{
+			T:     setting.Key,
+			D:     setting.DisplayValue(options),
+			IsDiv: false,
+		})
+	}
+	return items
+}
+
+// editSetting toggles bools, cycles enums and opens the inline editor for
+// ints and strings
+func (m MultiPageViewModel) editSetting(setting Setting) (tea.Model, tea.Cmd) {
+	switch setting.Type {
+	case BoolSetting:
+		value := setting.Bool(m.options)
+		*value = !*value
+		return m.applySetting(setting)
+
+	case EnumSetting:
+		value := setting.String(m.options)
+		choices := setting.Choices()
+		next := choices[0]
+		for i, choice := range choices {
+			if choice == *value {
+				next = choices[(i+1)%len(choices)]
+				break
+			}
+		}
+		*value = next
+		return m.applySetting(setting)
+
+	case IntSetting, StringSetting:
+		input := textinput.New()
+		input.Prompt = "› "
+		input.CharLimit = 512
+		if setting.Type == IntSetting {
+			input.SetValue(strconv.Itoa(*setting.Int(m.options)))
+			input.Placeholder = fmt.Sprintf("%d-%d", setting.Min, setting.Max)
+		} else {
+			input.SetValue(*setting.String(m.options))
+			input.Placeholder = setting.Description
+		}
+		input.CursorEnd()
+
+		m.settingInput = input
+		m.editingSetting = &setting
+		m.errorMessage = ""
+		return m, m.settingInput.Focus()
+
+	case JSONSetting:
+		// Live reload applies the edit once the editor exits
+		if m.actions != nil {
+			return m, m.actions.EditOptions()
+		}
+	}
+
+	return m, nil
+}
+
+// updateSettingInput handles keys while an int or string setting is being edited
+func (m MultiPageViewModel) updateSettingInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	setting := *m.editingSetting
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.editingSetting = nil
+		m.errorMessage = ""
+		return m, nil
+
+	case tea.KeyEnter:
+		input := strings.TrimSpace(m.settingInput.Value())
+		if setting.Type == IntSetting {
+			value, err := strconv.Atoi(input)
+			if err != nil || value < setting.Min || value > setting.Max {
+				m.errorMessage = fmt.Sprintf("%s must be a number between %d and %d", setting.Key, setting.Min, setting.Max)
+				return m, nil
+			}
+			*setting.Int(m.options) = value
+		} else {
+			*setting.String(m.options) = input
+		}
+		m.editingSetting = nil
+		return m.applySetting(setting)
+	}
+
+	var cmd tea.Cmd
+	m.settingInput, cmd = m.settingInput.Update(msg)
+	return m, cmd
+}
+
+// applySetting saves the options and applies the change to the running view
+func (m MultiPageViewModel) applySetting(setting Setting) (tea.Model, tea.Cmd) {
+	m.errorMessage = ""
+	if m.actions != nil {
+		if err := m.actions.SaveOptions(m.options); err != nil {
+			m.errorMessage = fmt.Sprintf("Failed to save options: %v", err)
+		}
+	}
+
+	var cmd tea.Cmd
+	if setting.OnChange != nil {
+		cmd = setting.OnChange(&m)
+	}
+	m.settingsList = buildSettingsList(m.options)
+
+	return m, cmd
+}
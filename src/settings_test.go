@@ -0,0 +1,62 @@
+package src
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Every option in options.json must show on the settings page
+func TestEveryOptionHasASetting(t *testing.T) {
+	options := reflect.TypeOf(OptionsDTO{})
+	for i := range options.NumField() {
+		name, _, _ := strings.Cut(options.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if _, ok := findSetting(name); !ok {
+			t.Errorf("option %s (OptionsDTO.%s) has no entry in Settings()", name, options.Field(i).Name)
+		}
+	}
+}
+
+func TestSettingsHaveTheirAccessor(t *testing.T) {
+	options := GetDefaultOptions()
+	for _, setting := range Settings() {
+		var ok bool
+		switch setting.Type {
+		case BoolSetting:
+			ok = setting.Bool != nil
+		case IntSetting:
+			ok = setting.Int != nil
+		case EnumSetting:
+			ok = setting.String != nil && setting.Choices != nil
+		case StringSetting:
+			ok = setting.String != nil
+		case JSONSetting:
+			ok = setting.Summary != nil
+		case ActionSetting:
+			ok = true
+		}
+		if !ok {
+			t.Errorf("setting %s lacks the accessor of its type", setting.Key)
+			continue
+		}
+		setting.DisplayValue(options)
+	}
+}
+
+func TestMouseNeedsFullScreen(t *testing.T) {
+	setting, _ := findSetting("mouse")
+	options := GetDefaultOptions()
+	options.Mouse = true
+
+	options.FullScreen = false
+	if value := setting.DisplayValue(options); !strings.Contains(value, "needs full_screen") {
+		t.Errorf("mouse without full_screen shows %q", value)
+	}
+	options.FullScreen = true
+	if value := setting.DisplayValue(options); strings.Contains(value, "needs") {
+		t.Errorf("mouse with full_screen shows %q", value)
+	}
+}
@@ -0,0 +1,138 @@
+package src
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// The wrapper evals the command tg leaves behind or puts it on the prompt,
+// the hook reports every cd to tg track. {{tg}} is replaced by the quoted
+// path of the binary. tg.sh and tg.fish load these too, so the integration
+// lives only here.
+const (
+	bashInit = `tg() {
+    {{tg}} "$@"
+
+    local cmd_file="$HOME/.terminal-gameplay/cmd-exec"
+    if [ -f "$cmd_file" ]; then
+        local cmd=$(cat "$cmd_file")
+        rm -f "$cmd_file"
+        eval "$cmd"
+    fi
+
+    local insert_file="$HOME/.terminal-gameplay/cmd-insert"
+    if [ -f "$insert_file" ]; then
+        local line=$(cat "$insert_file")
+        rm -f "$insert_file"
+        if [ "${BASH_VERSINFO[0]}" -ge 4 ]; then
+            read -e -i "$line" -p "> " line || return
+            history -s "$line"
+        else
+            # bash 3 has no read -i: the command is shown and put in history,
+            # up recalls it to edit and an empty line runs it as is
+            printf '%s\n' "$line"
+            history -s "$line"
+            local edited
+            read -e -p "> " edited || return
+            if [ -n "$edited" ]; then
+                line=$edited
+                history -s "$line"
+            fi
+        fi
+        eval "$line"
+    fi
+}
+
+__tg_track() {
+    [ "$PWD" = "$__tg_last_dir" ] && return
+    __tg_last_dir="$PWD"
+    ({{tg}} track "$PWD" >/dev/null 2>&1 &)
+}
+
+case ";${PROMPT_COMMAND};" in
+    *";__tg_track;"*) ;;
+    *) PROMPT_COMMAND="__tg_track${PROMPT_COMMAND:+;$PROMPT_COMMAND}" ;;
+esac
+`
+
+	zshInit = `tg() {
+    {{tg}} "$@"
+
+    local cmd_file="$HOME/.terminal-gameplay/cmd-exec"
+    if [ -f "$cmd_file" ]; then
+        local cmd=$(cat "$cmd_file")
+        rm -f "$cmd_file"
+        eval "$cmd"
+    fi
+
+    local insert_file="$HOME/.terminal-gameplay/cmd-insert"
+    if [ -f "$insert_file" ]; then
+        print -z -- "$(cat "$insert_file")"
+        rm -f "$insert_file"
+    fi
+}
+
+__tg_track() {
+    ({{tg}} track "$PWD" >/dev/null 2>&1 &)
+}
+
+autoload -Uz add-zsh-hook
+add-zsh-hook chpwd __tg_track
+`
+
+	fishInit = `function tg
+    {{tg}} $argv
+
+    set -l cmd_file $HOME/.terminal-gameplay/cmd-exec
+    if test -f $cmd_file
+        set -l cmd (cat $cmd_file)
+        rm -f $cmd_file
+        eval $cmd
+    end
+
+    set -l insert_file $HOME/.terminal-gameplay/cmd-insert
+    if test -f $insert_file
+        set -l line (string collect < $insert_file)
+        rm -f $insert_file
+        read -c "$line" -P '> ' line; and eval $line
+    end
+end
+
+function __tg_track --on-variable PWD
+    {{tg}} track $PWD >/dev/null 2>&1 &
+    disown
+end
+`
+)
+
+// Init prints the shell integration for the given shell, meant to be loaded
+// with eval "$(terminal-gameplay init zsh)" or `terminal-gameplay init fish | source`
+func (r *Runner) Init(args []string) {
+	if len(args) != 1 {
+		r.utils.ExitWithError("Usage: tg init <bash|zsh|fish>")
+	}
+
+	var script string
+	switch args[0] {
+	case BashShell:
+		script = bashInit
+	case ZshShell:
+		script = zshInit
+	case FishShell:
+		script = fishInit
+	default:
+		r.utils.ExitWithError(fmt.Sprintf("Unknown shell %q, expected bash, zsh or fish", args[0]))
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		r.utils.HandleError(err, "Failed to find the tg binary")
+	}
+	fmt.Print(strings.ReplaceAll(script, "{{tg}}", shellQuote(binary)))
+}
+
+// shellQuote quotes s for bash, zsh and fish
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
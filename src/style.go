@@ -46,42 +46,58 @@ type Styles struct {
 	SettingsValueColor         lipgloss.Color
 	SettingsEnabledColor       lipgloss.Color
 	SettingsDisabledColor      lipgloss.Color
+
+	// Border of item boxes and tab formats, set by the theme
+	Border            lipgloss.Border
+	SelectedTabFormat string
+	TabFormat         string
 }
 
+// DefaultStyles returns the styles of the active theme
 func DefaultStyles() *Styles {
+	return NewStyles(ResolveTheme(ActiveThemeName()))
+}
+
+// NewStyles builds the styles for a resolved theme
+func NewStyles(theme ThemeDTO) *Styles {
 	s := new(Styles)
+	c := theme.Colors
 
-	s.PeachColor = lipgloss.Color("#F2B391")
-	s.CoralColor = lipgloss.Color("#F39194")
-	s.OrchidColor = lipgloss.Color("#E3B5BF")
-	s.ThistleColor = lipgloss.Color("#DAC3E9")
-	s.NyanzaColor = lipgloss.Color("#E9F2D0")
-	s.ErrorColor = lipgloss.Color("#FF99B8")
-	s.AquamarineColor = lipgloss.Color("#B4F8D5")
-	s.DividerColor = lipgloss.Color("#6B6B6B")
+	s.PeachColor = lipgloss.Color(c.Peach)
+	s.CoralColor = lipgloss.Color(c.Coral)
+	s.OrchidColor = lipgloss.Color(c.Orchid)
+	s.ThistleColor = lipgloss.Color(c.Thistle)
+	s.NyanzaColor = lipgloss.Color(c.Nyanza)
+	s.ErrorColor = lipgloss.Color(c.Error)
+	s.AquamarineColor = lipgloss.Color(c.Aquamarine)
+	s.DividerColor = lipgloss.Color(c.Divider)
 
 	// Muted colors for unselected items
-	s.MutedTitleColor = lipgloss.Color("#6B6B6B")  // Subtle gray
-	s.MutedBorderColor = lipgloss.Color("#3A3A3A") // Very dark gray
+	s.MutedTitleColor = lipgloss.Color(c.MutedTitle)
+	s.MutedBorderColor = lipgloss.Color(c.MutedBorder)
 
 	// Search and highlight colors
-	s.SearchBoxColor = s.AquamarineColor
-	s.SearchTextColor = s.ThistleColor
-	s.HighlightBgColor = lipgloss.Color("#FFD700") // Gold/yellow
-	s.HighlightFgColor = lipgloss.Color("#1A1A1A") // Dark text for readability
-
-	// Settings colors - slightly desaturated/grayed versions of main palette
-	s.SettingsTitleColor = lipgloss.Color("#9B8B9F")         // Muted purple-gray (less saturated Orchid/Thistle)
-	s.SettingsSelectedTitleColor = lipgloss.Color("#C5B0C9") // Soft purple-gray (grayed Orchid)
-	s.SettingsBorderColor = lipgloss.Color("#7A6B7E")        // Medium purple-gray
-	s.SettingsValueColor = lipgloss.Color("#ADA0B0")         // Light purple-gray
-	s.SettingsEnabledColor = lipgloss.Color("#A8DDA8")       // Soft pastel green (harmonizes with Nyanza/Aquamarine)
-	s.SettingsDisabledColor = lipgloss.Color("#E8999D")      // Soft pastel red (harmonizes with Coral/ErrorColor)
-
-	s.BorderColor = s.OrchidColor
-	s.FooterColor = s.NyanzaColor
-	s.TitleColor = s.ThistleColor
-	s.SelectedTitleColor = s.OrchidColor
+	s.SearchBoxColor = lipgloss.Color(c.SearchBox)
+	s.SearchTextColor = lipgloss.Color(c.SearchText)
+	s.HighlightBgColor = lipgloss.Color(c.HighlightBg)
+	s.HighlightFgColor = lipgloss.Color(c.HighlightFg)
+
+	// Settings colors
+	s.SettingsTitleColor = lipgloss.Color(c.SettingsTitle)
+	s.SettingsSelectedTitleColor = lipgloss.Color(c.SettingsSelectedTitle)
+	s.SettingsBorderColor = lipgloss.Color(c.SettingsBorder)
+	s.SettingsValueColor = lipgloss.Color(c.SettingsValue)
+	s.SettingsEnabledColor = lipgloss.Color(c.SettingsEnabled)
+	s.SettingsDisabledColor = lipgloss.Color(c.SettingsDisabled)
+
+	s.BorderColor = lipgloss.Color(c.Border)
+	s.FooterColor = lipgloss.Color(c.Footer)
+	s.TitleColor = lipgloss.Color(c.Title)
+	s.SelectedTitleColor = lipgloss.Color(c.SelectedTitle)
+
+	s.Border = borderByName(theme.Border)
+	s.SelectedTabFormat = theme.SelectedTab
+	s.TabFormat = theme.Tab
 
 	s.InputField = lipgloss.NewStyle().
 		BorderForeground(s.BorderColor).
@@ -94,9 +94,11 @@ func (m textInputViewModel) View() string {
 	)
 }
 
-func TextFieldView(title, placeHolder string, endValue *string) {
+func TextFieldView(title, placeHolder, value string, endValue *string) {
 
 	m := TextFieldViewModel(title, placeHolder, endValue)
+	m.textInput.SetValue(value)
+	m.textInput.CursorEnd()
 
 	if _, err := tea.NewProgram(m).Run(); err != nil {
 		fmt.Println("TextFieldView -> ", err)
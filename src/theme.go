@@ -0,0 +1,360 @@
+package src
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Built-in theme names
+const (
+	DarkThemeName         = "dark"
+	LightThemeName        = "light"
+	HighContrastThemeName = "high-contrast"
+	MonochromeThemeName   = "monochrome"
+)
+
+// NoColor clears a color the extended theme sets, as an empty value inherits it
+const NoColor = "none"
+
+// ThemeDTO describes a color scheme. Custom themes can extend another theme
+// and only set the fields they change.
+type ThemeDTO struct {
+	Extends string      `json:"extends,omitempty"`
+	Colors  ThemeColors `json:"colors"`
+	// Border is one of "rounded", "normal", "thick", "double" or "hidden"
+	Border string `json:"border,omitempty"`
+	// Tab formats are fmt patterns for the page name, e.g. "[ %s ]"
+	SelectedTab string `json:"selected_tab,omitempty"`
+	Tab         string `json:"tab,omitempty"`
+}
+
+// ThemeColors holds one entry per color field of Styles
+type ThemeColors struct {
+	Footer        string `json:"footer,omitempty"`
+	Border        string `json:"border,omitempty"`
+	Title         string `json:"title,omitempty"`
+	SelectedTitle string `json:"selected_title,omitempty"`
+
+	Peach      string `json:"peach,omitempty"`
+	Coral      string `json:"coral,omitempty"`
+	Orchid     string `json:"orchid,omitempty"`
+	Thistle    string `json:"thistle,omitempty"`
+	Nyanza     string `json:"nyanza,omitempty"`
+	Aquamarine string `json:"aquamarine,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Divider    string `json:"divider,omitempty"`
+
+	MutedTitle  string `json:"muted_title,omitempty"`
+	MutedBorder string `json:"muted_border,omitempty"`
+
+	SearchBox   string `json:"search_box,omitempty"`
+	SearchText  string `json:"search_text,omitempty"`
+	HighlightBg string `json:"highlight_bg,omitempty"`
+	HighlightFg string `json:"highlight_fg,omitempty"`
+
+	SettingsTitle         string `json:"settings_title,omitempty"`
+	SettingsSelectedTitle string `json:"settings_selected_title,omitempty"`
+	SettingsBorder        string `json:"settings_border,omitempty"`
+	SettingsValue         string `json:"settings_value,omitempty"`
+	SettingsEnabled       string `json:"settings_enabled,omitempty"`
+	SettingsDisabled      string `json:"settings_disabled,omitempty"`
+}
+
+// Theme registry. User themes loaded at startup live next to the built-in
+// ones and may reuse a built-in name to tweak it.
+var (
+	builtinThemes = map[string]ThemeDTO{
+		DarkThemeName:         darkTheme(),
+		LightThemeName:        lightTheme(),
+		HighContrastThemeName: highContrastTheme(),
+		MonochromeThemeName:   monochromeTheme(),
+	}
+	customThemes    = map[string]ThemeDTO{}
+	activeThemeName = DarkThemeName
+)
+
+func darkTheme() ThemeDTO {
+	return ThemeDTO{
+		Colors: ThemeColors{
+			Peach:      "#F2B391",
+			Coral:      "#F39194",
+			Orchid:     "#E3B5BF",
+			Thistle:    "#DAC3E9",
+			Nyanza:     "#E9F2D0",
+			Error:      "#FF99B8",
+			Aquamarine: "#B4F8D5",
+			Divider:    "#6B6B6B",
+
+			// Muted colors for unselected items
+			MutedTitle:  "#6B6B6B", // Subtle gray
+			MutedBorder: "#3A3A3A", // Very dark gray
+
+			// Search and highlight colors
+			SearchBox:   "#B4F8D5",
+			SearchText:  "#DAC3E9",
+			HighlightBg: "#FFD700", // Gold/yellow
+			HighlightFg: "#1A1A1A", // Dark text for readability
+
+			// Settings colors - slightly desaturated/grayed versions of main palette
+			SettingsTitle:         "#9B8B9F", // Muted purple-gray (less saturated Orchid/Thistle)
+			SettingsSelectedTitle: "#C5B0C9", // Soft purple-gray (grayed Orchid)
+			SettingsBorder:        "#7A6B7E", // Medium purple-gray
+			SettingsValue:         "#ADA0B0", // Light purple-gray
+			SettingsEnabled:       "#A8DDA8", // Soft pastel green (harmonizes with Nyanza/Aquamarine)
+			SettingsDisabled:      "#E8999D", // Soft pastel red (harmonizes with Coral/ErrorColor)
+
+			Border:        "#E3B5BF",
+			Footer:        "#E9F2D0",
+			Title:         "#DAC3E9",
+			SelectedTitle: "#E3B5BF",
+		},
+		Border:      "rounded",
+		SelectedTab: "[ %s ]",
+		Tab:         "  %s  ",
+	}
+}
+
+func lightTheme() ThemeDTO {
+	return ThemeDTO{
+		Colors: ThemeColors{
+			Peach:      "#B5582A",
+			Coral:      "#C23B40",
+			Orchid:     "#9C3D6E",
+			Thistle:    "#6C4A8C",
+			Nyanza:     "#3F5E1F",
+			Error:      "#C0134B",
+			Aquamarine: "#1D7A55",
+			Divider:    "#8A8A8A",
+
+			MutedTitle:  "#7A7A7A",
+			MutedBorder: "#C8C8C8",
+
+			SearchBox:   "#1D7A55",
+			SearchText:  "#6C4A8C",
+			HighlightBg: "#FFE066",
+			HighlightFg: "#1A1A1A",
+
+			SettingsTitle:         "#6E5F72",
+			SettingsSelectedTitle: "#5B3F63",
+			SettingsBorder:        "#A89AAB",
+			SettingsValue:         "#6E5F72",
+			SettingsEnabled:       "#2E7D32",
+			SettingsDisabled:      "#B23A48",
+
+			Border:        "#9C3D6E",
+			Footer:        "#3F5E1F",
+			Title:         "#6C4A8C",
+			SelectedTitle: "#9C3D6E",
+		},
+		Border:      "rounded",
+		SelectedTab: "[ %s ]",
+		Tab:         "  %s  ",
+	}
+}
+
+func highContrastTheme() ThemeDTO {
+	return ThemeDTO{
+		Colors: ThemeColors{
+			Peach:      "#FFAF00",
+			Coral:      "#FF5F5F",
+			Orchid:     "#FF87FF",
+			Thistle:    "#FFFFFF",
+			Nyanza:     "#FFFFFF",
+			Error:      "#FF0000",
+			Aquamarine: "#00FF87",
+			Divider:    "#FFFFFF",
+
+			MutedTitle:  "#D0D0D0",
+			MutedBorder: "#A8A8A8",
+
+			SearchBox:   "#00FFFF",
+			SearchText:  "#FFFFFF",
+			HighlightBg: "#FFFF00",
+			HighlightFg: "#000000",
+
+			SettingsTitle:         "#D0D0D0",
+			SettingsSelectedTitle: "#FFFF00",
+			SettingsBorder:        "#A8A8A8",
+			SettingsValue:         "#FFFFFF",
+			SettingsEnabled:       "#00FF00",
+			SettingsDisabled:      "#FF0000",
+
+			Border:        "#FFFF00",
+			Footer:        "#FFFFFF",
+			Title:         "#FFFFFF",
+			SelectedTitle: "#FFFF00",
+		},
+		Border:      "thick",
+		SelectedTab: "▶ %s ◀",
+		Tab:         "  %s  ",
+	}
+}
+
+// monochromeTheme sets no colors, selection is shown with borders, bold and indentation
+func monochromeTheme() ThemeDTO {
+	return ThemeDTO{
+		Border:      "normal",
+		SelectedTab: "[ %s ]",
+		Tab:         "  %s  ",
+	}
+}
+
+// RegisterTheme adds or replaces a user theme in the registry
+func RegisterTheme(name string, theme ThemeDTO) {
+	customThemes[name] = theme
+}
+
+// ValidateTheme rejects tab formats that do not hold the page name exactly once
+func ValidateTheme(name string, theme ThemeDTO) error {
+	formats := []struct{ key, format string }{
+		{"selected_tab", theme.SelectedTab},
+		{"tab", theme.Tab},
+	}
+	for _, f := range formats {
+		if f.format == "" {
+			continue
+		}
+		verbs := strings.ReplaceAll(f.format, "%%", "")
+		if strings.Count(verbs, "%") != 1 || strings.Count(verbs, "%s") != 1 {
+			return fmt.Errorf("Theme %s: %s must contain %%s exactly once, got %q", name, f.key, f.format)
+		}
+	}
+	return nil
+}
+
+// clearCustomThemes removes the user themes before they are loaded again
+func clearCustomThemes() {
+	customThemes = map[string]ThemeDTO{}
+}
+
+// ThemeNames returns the names of all registered themes, built-in themes first
+func ThemeNames() []string {
+	names := []string{DarkThemeName, LightThemeName, HighContrastThemeName, MonochromeThemeName}
+
+	custom := []string{}
+	for name := range customThemes {
+		if _, ok := builtinThemes[name]; !ok {
+			custom = append(custom, name)
+		}
+	}
+	sort.Strings(custom)
+
+	return append(names, custom...)
+}
+
+// SetActiveTheme selects the theme used by DefaultStyles. Unknown names fall back to dark.
+func SetActiveTheme(name string) {
+	_, builtin := builtinThemes[name]
+	_, custom := customThemes[name]
+	if !builtin && !custom {
+		name = DarkThemeName
+	}
+	activeThemeName = name
+}
+
+// ActiveThemeName returns the name of the theme used by DefaultStyles
+func ActiveThemeName() string {
+	return activeThemeName
+}
+
+// ResolveTheme returns a theme with the fields it leaves empty filled from the
+// theme it extends (dark when not set). NO_COLOR forces the monochrome theme.
+func ResolveTheme(name string) ThemeDTO {
+	if os.Getenv("NO_COLOR") != "" {
+		return monochromeTheme()
+	}
+	return resolveTheme(name, map[string]bool{})
+}
+
+func resolveTheme(name string, seen map[string]bool) ThemeDTO {
+	theme, ok := customThemes[name]
+	if !ok || seen[name] {
+		if builtin, ok := builtinThemes[name]; ok {
+			return builtin
+		}
+		return darkTheme()
+	}
+
+	// A user theme named like a built-in one extends it by default
+	baseName := theme.Extends
+	if baseName == "" {
+		baseName = DarkThemeName
+		if _, ok := builtinThemes[name]; ok {
+			baseName = name
+		}
+	}
+
+	// Guard against themes extending each other in a loop
+	seen[name] = true
+	return mergeThemes(resolveTheme(baseName, seen), theme)
+}
+
+// mergeThemes overlays the non-empty fields of theme on top of base.
+// A color set to NoColor ends up empty.
+func mergeThemes(base, theme ThemeDTO) ThemeDTO {
+	pick := func(value, fallback string) string {
+		if value != "" {
+			return value
+		}
+		return fallback
+	}
+	pickColor := func(value, fallback string) string {
+		if value == NoColor {
+			return ""
+		}
+		return pick(value, fallback)
+	}
+
+	b, t := base.Colors, theme.Colors
+	return ThemeDTO{
+		Colors: ThemeColors{
+			Footer:                pickColor(t.Footer, b.Footer),
+			Border:                pickColor(t.Border, b.Border),
+			Title:                 pickColor(t.Title, b.Title),
+			SelectedTitle:         pickColor(t.SelectedTitle, b.SelectedTitle),
+			Peach:                 pickColor(t.Peach, b.Peach),
+			Coral:                 pickColor(t.Coral, b.Coral),
+			Orchid:                pickColor(t.Orchid, b.Orchid),
+			Thistle:               pickColor(t.Thistle, b.Thistle),
+			Nyanza:                pickColor(t.Nyanza, b.Nyanza),
+			Aquamarine:            pickColor(t.Aquamarine, b.Aquamarine),
+			Error:                 pickColor(t.Error, b.Error),
+			Divider:               pickColor(t.Divider, b.Divider),
+			MutedTitle:            pickColor(t.MutedTitle, b.MutedTitle),
+			MutedBorder:           pickColor(t.MutedBorder, b.MutedBorder),
+			SearchBox:             pickColor(t.SearchBox, b.SearchBox),
+			SearchText:            pickColor(t.SearchText, b.SearchText),
+			HighlightBg:           pickColor(t.HighlightBg, b.HighlightBg),
+			HighlightFg:           pickColor(t.HighlightFg, b.HighlightFg),
+			SettingsTitle:         pickColor(t.SettingsTitle, b.SettingsTitle),
+			SettingsSelectedTitle: pickColor(t.SettingsSelectedTitle, b.SettingsSelectedTitle),
+			SettingsBorder:        pickColor(t.SettingsBorder, b.SettingsBorder),
+			SettingsValue:         pickColor(t.SettingsValue, b.SettingsValue),
+			SettingsEnabled:       pickColor(t.SettingsEnabled, b.SettingsEnabled),
+			SettingsDisabled:      pickColor(t.SettingsDisabled, b.SettingsDisabled),
+		},
+		Border:      pick(theme.Border, base.Border),
+		SelectedTab: pick(theme.SelectedTab, base.SelectedTab),
+		Tab:         pick(theme.Tab, base.Tab),
+	}
+}
+
+// borderByName maps a theme border name to a lipgloss border
+func borderByName(name string) lipgloss.Border {
+	switch name {
+	case "normal":
+		return lipgloss.NormalBorder()
+	case "thick":
+		return lipgloss.ThickBorder()
+	case "double":
+		return lipgloss.DoubleBorder()
+	case "hidden":
+		return lipgloss.HiddenBorder()
+	default:
+		return lipgloss.RoundedBorder()
+	}
+}
@@ -0,0 +1,147 @@
+package src
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// Visits a directory needs before it is suggested
+	suggestionMinVisits = 3
+	// Most suggestions shown on the Frequent page
+	maxSuggestions = 5
+)
+
+// Track records a visit to a directory, called by the shell hook on every cd.
+// It reads only what it needs so cd stays fast.
+func (r *Runner) Track(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Track -> expected one directory")
+	}
+	dir, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("Track -> %v", err)
+	}
+
+	if err := r.fileManager.BasicSetup(); err != nil {
+		return fmt.Errorf("Track -> %v", err)
+	}
+
+	optionsContent, err := r.fileManager.GetOptionsContent()
+	if err != nil {
+		return fmt.Errorf("Track -> %v", err)
+	}
+	options := GetDefaultOptions()
+	if optionsContent != "" {
+		if options, err = ParseJSONContentWithDefaults(optionsContent, GetDefaultOptions()); err != nil {
+			return fmt.Errorf("Track -> %v", err)
+		}
+	}
+	if !options.TrackVisits {
+		return nil
+	}
+
+	_, err = UpdateGoToFrequency(r.fileManager, func(goToFrequency *GoToFrequencyDTO) {
+		goToFrequency.TrackVisit(dir)
+	})
+	if err != nil {
+		return fmt.Errorf("Track -> %v", err)
+	}
+	return nil
+}
+
+// buildSuggestionList lists the most visited directories that aren't in goTo
+func buildSuggestionList(config *ConfigDTO, goToFrequency *GoToFrequencyDTO) []ListItem {
+	if len(goToFrequency.Visits) == 0 {
+		return nil
+	}
+
+	utils := NewUtils()
+	skip := map[string]bool{string(filepath.Separator): true}
+	if home, err := os.UserHomeDir(); err == nil {
+		skip[home] = true
+	}
+	for _, entry := range goToEntries(config) {
+		skip[filepath.Clean(utils.ExpandPath(entry.Value))] = true
+	}
+
+	dirs := []string{}
+	for dir, visits := range goToFrequency.Visits {
+		if visits >= suggestionMinVisits && !skip[dir] {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if goToFrequency.Visits[dirs[i]] != goToFrequency.Visits[dirs[j]] {
+			return goToFrequency.Visits[dirs[i]] > goToFrequency.Visits[dirs[j]]
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	items := []ListItem{}
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		items = append(items, ListItem{T: filepath.Base(dir), D: abbreviateHome(dir), Suggestion: true})
+		if len(items) == maxSuggestions {
+			break
+		}
+	}
+	return items
+}
+
+// promoteSuggestion saves the selected suggestion to goTo, keeping its visits
+// as its frequency
+func (m MultiPageViewModel) promoteSuggestion() (tea.Model, tea.Cmd) {
+	item, ok := m.selectedItem()
+	if !ok || m.actions == nil {
+		return m, nil
+	}
+	if !item.Suggestion {
+		m.errorMessage = fmt.Sprintf("%s is already in goTo", item.T)
+		return m, nil
+	}
+
+	dir := filepath.Clean(m.actions.utils.ExpandPath(item.D))
+	label := importLabels([]ImportEntry{{Path: dir}}, m.config.GoTo)[0]
+	insertInSection(&m.config.GoTo, "", label, item.D)
+
+	// Seeded on the file as it is now, the shell hook may have added visits
+	saved, frequencyErr := m.actions.UpdateGoToFrequency(func(goToFrequency *GoToFrequencyDTO) {
+		goToFrequency.SeedGoTo(label, goToFrequency.Visits[dir])
+	})
+	if frequencyErr == nil {
+		*m.goToFrequency = *saved
+	} else {
+		m.goToFrequency.SeedGoTo(label, m.goToFrequency.Visits[dir])
+	}
+
+	m.errorMessage = ""
+	m.setConfig(m.config)
+
+	// The entry moved up among the frequent ones
+	for i, listItem := range m.getActiveList() {
+		if !listItem.IsDiv && !listItem.Suggestion && listItem.T == label {
+			m.cursor = i
+			m.updateLayout()
+			break
+		}
+	}
+
+	if err := m.actions.SaveConfig(m.config); err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to save config: %v", err)
+		return m, nil
+	}
+	if frequencyErr != nil {
+		m.errorMessage = fmt.Sprintf("Failed to save goTo frequency: %v", frequencyErr)
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		return actionResultMsg{status: fmt.Sprintf("✓ Saved %s to goTo as %q", item.D, label)}
+	}
+}
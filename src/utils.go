@@ -1,9 +1,12 @@
 package src
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"os/user"
 	"path/filepath"
 	"strings"
 )
@@ -13,8 +16,11 @@ type UtilsInterface interface {
 	ExitWithError(message string)
 	HandleError(err error, message string)
 	ExpandPath(path string) string
-	ExecuteCommand(command string) error
-	CopyToClipboard(text string) error
+	ResolveValue(value string) (string, error)
+	ResolvePath(value string) (string, error)
+	ExecuteCommand(ctx context.Context, command, dir string, output io.Writer) (int, error)
+	CopyToClipboard(text, backend string) error
+	PasteFromClipboard(backend string) (string, error)
 	ChangeDirectory(path string) error
 }
 
@@ -46,44 +52,119 @@ func (u *Utils) HandleError(err error, message string) {
 	}
 }
 
+// ExpandPath expands a leading ~ or ~user and the $VAR, ${VAR} and
+// ${VAR:-default} environment variables
 func (u *Utils) ExpandPath(path string) string {
-	if strings.HasPrefix(path, "~/") {
-		home, err := os.UserHomeDir()
+	return expandTilde(os.Expand(path, expandVariable))
+}
+
+// expandTilde expands a leading ~ or ~user to the home directory
+func expandTilde(path string) string {
+	if !strings.HasPrefix(path, "~") {
+		return path
+	}
+
+	name, rest, _ := strings.Cut(path[1:], "/")
+	var home string
+	if name == "" {
+		dir, err := os.UserHomeDir()
+		if err != nil {
+			return path
+		}
+		home = dir
+	} else {
+		account, err := user.Lookup(name)
 		if err != nil {
 			return path
 		}
-		return filepath.Join(home, path[2:])
+		home = account.HomeDir
 	}
-	return path
+	return filepath.Join(home, rest)
 }
 
-func (u *Utils) ExecuteCommand(command string) error {
-	cmd := exec.Command("sh", "-c", command)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	return cmd.Run()
+// expandVariable looks up a variable for os.Expand, supporting the
+// ${VAR:-default} form used by shells
+func expandVariable(name string) string {
+	name, fallback, hasFallback := strings.Cut(name, ":-")
+	if value := os.Getenv(name); value != "" || !hasFallback {
+		return value
+	}
+	return fallback
 }
 
-func (u *Utils) CopyToClipboard(text string) error {
-	// Try pbcopy (macOS)
-	cmd := exec.Command("pbcopy")
-	cmd.Stdin = strings.NewReader(text)
-	if err := cmd.Run(); err == nil {
-		return nil
+// ExecuteCommand runs command with sh in dir, writing stdout and stderr to
+// output, and returns its exit code. Canceling ctx stops the command and
+// everything it started. An error means it couldn't run or was stopped.
+func (u *Utils) ExecuteCommand(ctx context.Context, command, dir string, output io.Writer) (int, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// A process group of its own, so stopping it reaches its children too
+	detachProcess(cmd)
+	cmd.Cancel = func() error {
+		return stopProcessGroup(cmd)
+	}
+	// Children ignoring SIGTERM may hold the output open
+	cmd.WaitDelay = commandWaitDelay
+
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok && ctx.Err() == nil {
+		return exitErr.ExitCode(), nil
 	}
+	if err != nil {
+		return -1, fmt.Errorf("ExecuteCommand -> %v", err)
+	}
+	return 0, nil
+}
 
-	// Try xclip (Linux)
-	cmd = exec.Command("xclip", "-selection", "clipboard")
-	cmd.Stdin = strings.NewReader(text)
-	if err := cmd.Run(); err == nil {
+// CopyToClipboard copies text with the given backend. "auto" tries each
+// backend in turn and the error lists why each one failed.
+func (u *Utils) CopyToClipboard(text, backend string) error {
+	if backend != "" && backend != AutoClipboard {
+		if err := copyWithBackend(backend, text); err != nil {
+			return fmt.Errorf("%s: %v", backend, err)
+		}
 		return nil
 	}
 
-	// Try xsel (Linux alternative)
-	cmd = exec.Command("xsel", "--clipboard", "--input")
-	cmd.Stdin = strings.NewReader(text)
-	return cmd.Run()
+	tried := []string{}
+	for _, b := range clipboardOrder() {
+		err := copyWithBackend(b, text)
+		if err == nil {
+			return nil
+		}
+		tried = append(tried, fmt.Sprintf("%s: %v", b, err))
+	}
+	return fmt.Errorf("no clipboard backend worked (tried %s)", strings.Join(tried, ", "))
+}
+
+// PasteFromClipboard reads the clipboard with the given backend, trying each
+// one that can read in turn for "auto"
+func (u *Utils) PasteFromClipboard(backend string) (string, error) {
+	if backend != "" && backend != AutoClipboard {
+		text, err := pasteWithBackend(backend)
+		if err != nil {
+			return "", fmt.Errorf("%s: %v", backend, err)
+		}
+		return text, nil
+	}
+
+	tried := []string{}
+	for _, b := range clipboardOrder() {
+		if b == OSC52Clipboard {
+			continue
+		}
+		text, err := pasteWithBackend(b)
+		if err == nil {
+			return text, nil
+		}
+		tried = append(tried, fmt.Sprintf("%s: %v", b, err))
+	}
+	if len(tried) == 0 {
+		return "", fmt.Errorf("the clipboard can't be read over SSH, set the clipboard option to a local tool")
+	}
+	return "", fmt.Errorf("no clipboard backend worked (tried %s)", strings.Join(tried, ", "))
 }
 
 func (u *Utils) ChangeDirectory(path string) error {
@@ -2,8 +2,9 @@ package src
 
 type ViewBuilderInterface interface {
 	NewListView(title string, op []ListItem, height int) ListItem
-	NewTextFieldView(title, placeHolder string) string
-	NewMultiPageView(config *ConfigDTO, options *OptionsDTO, goToFrequency *GoToFrequencyDTO) string
+	NewMultiSelectListView(title string, op []ListItem, height int, checked bool) []ListItem
+	NewTextFieldView(title, placeHolder, value string) string
+	NewMultiPageView(config *ConfigDTO, options *OptionsDTO, goToFrequency *GoToFrequencyDTO, actions *Actions) Selection
 }
 
 type ViewBuilder struct{}
@@ -18,14 +19,23 @@ func (b *ViewBuilder) NewListView(title string, op []ListItem, height int) ListI
 	return endValue
 }
 
-func (b *ViewBuilder) NewTextFieldView(title, placeHolder string) string {
+// NewMultiSelectListView returns the checked items, or nil when cancelled
+func (b *ViewBuilder) NewMultiSelectListView(title string, op []ListItem, height int, checked bool) []ListItem {
+	var endValue []ListItem
+	MultiSelectListView(title, op, height, checked, &endValue)
+	return endValue
+}
+
+// NewTextFieldView asks for a value, starting from value. It returns
+// ExitSignal when cancelled.
+func (b *ViewBuilder) NewTextFieldView(title, placeHolder, value string) string {
 	endValue := ""
-	TextFieldView(title, placeHolder, &endValue)
+	TextFieldView(title, placeHolder, value, &endValue)
 	return endValue
 }
 
-func (b *ViewBuilder) NewMultiPageView(config *ConfigDTO, options *OptionsDTO, goToFrequency *GoToFrequencyDTO) string {
-	selected := ""
-	MultiPageView(config, options, goToFrequency, &selected)
+func (b *ViewBuilder) NewMultiPageView(config *ConfigDTO, options *OptionsDTO, goToFrequency *GoToFrequencyDTO, actions *Actions) Selection {
+	selected := Selection{}
+	MultiPageView(config, options, goToFrequency, actions, &selected)
 	return selected
 }
@@ -2,22 +2,8 @@
 # Add this to your ~/.config/fish/config.fish:
 #
 #   source /path/to/tg.fish
+#
+# The integration itself is printed by `terminal-gameplay init`, so this file
+# only loads it.
 
-function tg
-    # Run the binary in the same directory
-    $HOME/.terminal-gameplay/terminal-gameplay
-    
-    # Check if command file exists
-    set -l cmd_file $HOME/.terminal-gameplay/cmd-exec
-    
-    if test -f $cmd_file
-        # Read the command
-        set -l cmd (cat $cmd_file)
-        
-        # Delete the file immediately
-        rm -f $cmd_file
-        
-        # Execute the command in current shell
-        eval $cmd
-    end
-end
+$HOME/.terminal-gameplay/terminal-gameplay init fish | source
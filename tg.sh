@@ -4,22 +4,12 @@
 # Add this to your ~/.bashrc or ~/.zshrc:
 #
 #   source /path/to/tg.sh
+#
+# The integration itself is printed by `terminal-gameplay init`, so this file
+# only picks the shell and loads it.
 
-tg() {
-    # Run the binary from ~/.terminal-gameplay/tg
-    $HOME/.terminal-gameplay/terminal-gameplay
-    
-    # Check if command file exists
-    local cmd_file="$HOME/.terminal-gameplay/cmd-exec"
-    
-    if [ -f "$cmd_file" ]; then
-        # Read the command
-        local cmd=$(cat "$cmd_file")
-        
-        # Delete the file immediately
-        rm -f "$cmd_file"
-        
-        # Execute the command in current shell
-        eval "$cmd"
-    fi
-}
+if [ -n "$ZSH_VERSION" ]; then
+    eval "$("$HOME/.terminal-gameplay/terminal-gameplay" init zsh)"
+elif [ -n "$BASH_VERSION" ]; then
+    eval "$("$HOME/.terminal-gameplay/terminal-gameplay" init bash)"
+fi